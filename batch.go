@@ -0,0 +1,241 @@
+//
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+//
+// @project queryman
+// @author agent
+// @date 2026. 10. 19. AM 12:20
+//
+
+package queryman
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"time"
+)
+
+// BatchMode decides how ExecuteBatch handles error of an item
+type BatchMode int
+
+const (
+	// BatchFailFast stops at the first failed item
+	BatchFailFast BatchMode = iota
+	// BatchContinue executes every item. in a transaction, each item runs in a savepoint
+	BatchContinue
+	// BatchInTransaction executes items in a transaction which is rolled back at the first failed item.
+	// in a transaction, items run in a savepoint which is rolled back at the first failed item
+	BatchInTransaction
+)
+
+const batchSavepoint = "queryman_batch"
+
+// BatchItem is the result of an item of batch
+type BatchItem struct {
+	Index        int
+	RowsAffected int64
+	InsertId     int64
+	Err          error
+}
+
+// BatchResult lists results of executed items in order
+type BatchResult struct {
+	items       []BatchItem
+	rowAffected int64
+}
+
+func (r *BatchResult) add(item BatchItem) {
+	r.items = append(r.items, item)
+	if item.Err == nil {
+		r.rowAffected += item.RowsAffected
+	}
+}
+
+// LastInsertId returns generated id of the first succeeded item
+func (r BatchResult) LastInsertId() (int64, error) {
+	for _, v := range r.items {
+		if v.Err == nil && v.InsertId != 0 {
+			return v.InsertId, nil
+		}
+	}
+	return 0, ErrNoInsertId
+}
+
+// RowsAffected returns sum of affected rows of succeeded items
+func (r BatchResult) RowsAffected() (int64, error) {
+	return r.rowAffected, nil
+}
+
+func (r BatchResult) Items() []BatchItem {
+	return r.items
+}
+
+func (r BatchResult) Failed() []BatchItem {
+	failed := make([]BatchItem, 0)
+	for _, v := range r.items {
+		if v.Err != nil {
+			failed = append(failed, v)
+		}
+	}
+	return failed
+}
+
+// ExecuteBatch executes statement once per item of rows (slice of struct, map or parameter list).
+// error of BatchContinue is ErrBatchItemFailed when any item failed
+func (man *QueryMan) ExecuteBatch(ctx context.Context, stmtIdOrUserQuery string, rows interface{}, mode BatchMode) (BatchResult, error) {
+	if tx := man.joinedTx(ctx); tx != nil {
+		return tx.ExecuteBatch(ctx, stmtIdOrUserQuery, rows, mode)
+	}
+
+	stmt, err := man.find(stmtIdOrUserQuery)
+	if err != nil {
+		return BatchResult{}, err
+	}
+	if stmt.eleType != eleTypeInsert && stmt.eleType != eleTypeUpdate {
+		return BatchResult{}, ErrExecutionInvalidSqlType
+	}
+
+	return executeBatch(man.primary(ctx), stmt, rows, mode)
+}
+
+// ExecuteBatch executes items in the transaction. BatchInTransaction runs items in a savepoint of the transaction
+func (t *DBTransaction) ExecuteBatch(ctx context.Context, id string, rows interface{}, mode BatchMode) (BatchResult, error) {
+	stmt, err := t.queryFinder.find(id)
+	if err != nil {
+		return BatchResult{}, err
+	}
+	if stmt.eleType != eleTypeInsert && stmt.eleType != eleTypeUpdate {
+		return BatchResult{}, ErrExecutionInvalidSqlType
+	}
+	if t.readOnly {
+		return BatchResult{}, ErrReadOnlyTransaction
+	}
+
+	return executeBatch(t.proxy(ctx), stmt, rows, mode)
+}
+
+func executeBatch(sqlProxy SqlProxy, stmt QueryStatement, rows interface{}, mode BatchMode) (BatchResult, error) {
+	if stmt.hasArrayBind() {
+		return BatchResult{}, fmt.Errorf("batch of [%s] : IN clause array is not supported", stmt.Id)
+	}
+	if stmt.HasCondition() {
+		// statement is prepared once, so conditional fragments can't be refined per item
+		return BatchResult{}, ErrBatchDynamicStatement
+	}
+	list := reflect.ValueOf(rows)
+	if list.Kind() != reflect.Slice && list.Kind() != reflect.Array {
+		return BatchResult{}, fmt.Errorf("batch rows should be slice or array : %T", rows)
+	}
+
+	if mode != BatchInTransaction {
+		result, err := runBatchItems(sqlProxy, stmt, list, mode == BatchFailFast, mode == BatchContinue && sqlProxy.isTransaction())
+		if err == nil && len(result.Failed()) > 0 {
+			err = ErrBatchItemFailed
+		}
+		return result, err
+	}
+
+	var result BatchResult
+	if sqlProxy.isTransaction() {
+		// items applied before the failure are rolled back to savepoint. transaction itself is kept
+		dialect := stmt.getNormalizer().getDialect()
+		if err := execBatchSavepoint(sqlProxy, dialect, SavepointCreate); err != nil {
+			return result, err
+		}
+		result, err := runBatchItems(sqlProxy, stmt, list, true, false)
+		return result, finishBatchSavepoint(sqlProxy, dialect, err)
+	}
+
+	err := runInBatch(sqlProxy, func(sqlProxy SqlProxy) error {
+		var err error
+		result, err = runBatchItems(sqlProxy, stmt, list, true, false)
+		return err
+	})
+	return result, err
+}
+
+// runBatchItems executes items with a prepared statement. result lists items executed until the stop
+func runBatchItems(sqlProxy SqlProxy, stmt QueryStatement, list reflect.Value, stop bool, savepoint bool) (BatchResult, error) {
+	result := BatchResult{items: make([]BatchItem, 0, list.Len())}
+	pstmt, err := sqlProxy.prepare(stmt.Query)
+	if err != nil {
+		return result, err
+	}
+	defer pstmt.Close()
+
+	sqlProxy.debugPrint("[%s] batch of %d items", stmt.Id, list.Len())
+	binder := newQuerymanBulk(sqlProxy, stmt, NewBulkOptions())
+	dialect := stmt.getNormalizer().getDialect()
+	for i := 0; i < list.Len(); i++ {
+		item := BatchItem{Index: i}
+		binder.Reset()
+		item.Err = binder.AddBatch(list.Index(i).Interface())
+		if item.Err == nil && binder.Len() != 1 {
+			item.Err = fmt.Errorf("batch item should be a row : %d rows", binder.Len())
+		}
+
+		if item.Err == nil {
+			if savepoint {
+				item.Err = execBatchSavepoint(sqlProxy, dialect, SavepointCreate)
+			}
+			if item.Err == nil {
+				start := time.Now()
+				executed := ExecMultiResult{}
+				item.Err = execPrepared(pstmt, stmt, &executed, binder.params...)
+				sqlProxy.recordExcution(stmt.Id, start)
+				item.RowsAffected = executed.rowAffected
+				if len(executed.idList) > 0 {
+					item.InsertId = executed.idList[0]
+				}
+				if savepoint {
+					item.Err = finishBatchSavepoint(sqlProxy, dialect, item.Err)
+				}
+			}
+		}
+
+		result.add(item)
+		if item.Err != nil {
+			// caller gets every error from result. so it is printed only in debug mode
+			sqlProxy.debugPrint("[%s] batch item %d failed : %s", stmt.Id, i, item.Err.Error())
+			if stop {
+				return result, item.Err
+			}
+		}
+	}
+	return result, nil
+}
+
+func execBatchSavepoint(sqlProxy SqlProxy, dialect Dialect, action SavepointAction) error {
+	query := dialect.Savepoint(action, batchSavepoint)
+	if len(query) == 0 {
+		return nil
+	}
+	_, err := sqlProxy.exec(query)
+	return err
+}
+
+// finishBatchSavepoint rolls back failed item so that the transaction is usable for next item
+func finishBatchSavepoint(sqlProxy SqlProxy, dialect Dialect, cause error) error {
+	if cause == nil {
+		return execBatchSavepoint(sqlProxy, dialect, SavepointRelease)
+	}
+	if err := execBatchSavepoint(sqlProxy, dialect, SavepointRollback); err != nil {
+		return fmt.Errorf("%w (fail to rollback to savepoint : %s)", cause, err.Error())
+	}
+	return cause
+}
@@ -20,18 +20,73 @@ import (
 	"fmt"
 	"database/sql/driver"
 	"database/sql"
-	"strings"
+	"sync"
+	"time"
 	)
 
+// Bulk collects rows with AddBatch. rows are cleared after successful Execute,
+// and kept after failed one until Reset
 type Bulk interface {
 	AddBatch(params ...interface{}) error
 	Execute() (sql.Result, error)
+	// ExecuteAndReset executes rows and clears them regardless of the result
+	ExecuteAndReset() (sql.Result, error)
+	Reset()
+	// Len returns number of rows
+	Len() int
+	// EstimatedSize returns estimated bytes of parameters sent to server
+	EstimatedSize() int
 }
 
-func newQuerymanBulk(sqlProxy SqlProxy, stmt QueryStatement)	*querymanBulk {
+// DefaultBulkMaxBytes is estimated size limit of a bulk insert chunk
+const DefaultBulkMaxBytes = 1 << 20
+
+// BulkOptions configures chunking of bulk insert. MaxPlaceholders 0 means the limit of dialect.
+// MaxBytes is estimated size of statement and parameters. chunks run in a transaction with InTransaction.
+// InsertIdStep is the increment of generated ids. 0 means the setting of database (auto_increment_increment of MySQL).
+// Mode selects how bulk insert is executed
+type BulkOptions struct {
+	MaxPlaceholders int
+	MaxBytes        int
+	InTransaction   bool
+	InsertIdStep    int64
+	Mode            BulkMode
+}
+
+type BulkMode int
+
+const (
+	// BulkModeValues executes multi row INSERT ... VALUES
+	BulkModeValues BulkMode = iota
+	// BulkModeNative uses NativeLoader registered for the dialect (e.g. LOAD DATA LOCAL INFILE of loaddata package).
+	// generated ids are not collected, and multi row insert is used when no loader or statement is supported
+	BulkModeNative
+)
+
+func NewBulkOptions() BulkOptions {
+	opts := BulkOptions{}
+	opts.MaxPlaceholders = 0
+	opts.MaxBytes = DefaultBulkMaxBytes
+	opts.InTransaction = false
+	opts.InsertIdStep = 0
+	opts.Mode = BulkModeValues
+	return opts
+}
+
+// checkBulkStatement refuses statement which can't be bound per row.
+// IN clause array expands placeholders per execution, so it is not supported in bulk
+func checkBulkStatement(stmt QueryStatement) error {
+	if stmt.hasArrayBind() {
+		return fmt.Errorf("bulk of [%s] : IN clause array is not supported", stmt.Id)
+	}
+	return nil
+}
+
+func newQuerymanBulk(sqlProxy SqlProxy, stmt QueryStatement, opts BulkOptions)	*querymanBulk {
 	b := &querymanBulk{}
 	b.sqlProxy = sqlProxy
 	b.stmt = stmt
+	b.opts = opts
 	b.params = make([]interface{}, 0)
 	stmt.HasCondition()
 	return b
@@ -41,7 +96,11 @@ type querymanBulk struct {
 	stmt 		QueryStatement
 	sqlProxy 	SqlProxy
 	params		[]interface{}
+	rowSizes	[]int
+	rowBytes	[]int
+	targets		[]interface{}
 	execCount 	int
+	opts		BulkOptions
 }
 func (b *querymanBulk) String() string	{
 	return fmt.Sprintf("stmt=[%s], execCount=[%d], params.len=[%d]", b.stmt.Query, b.execCount, len(b.params))
@@ -52,12 +111,22 @@ func (b *querymanBulk) AddBatch(params ...interface{}) (err error)	{
 		return nil
 	}
 
+	rows := b.execCount
 	defer func() {
 		if r := recover(); r != nil {
 			err = fmt.Errorf("fail to execute : %s", r)
 		}
+		// rows of failed AddBatch are discarded together
+		if err != nil {
+			b.truncate(rows)
+		}
 	}()
 
+	err = checkParameter(b.stmt, params...)
+	if err != nil {
+		return
+	}
+
 	atype := reflect.TypeOf(params[0])
 	val := params[0]
 
@@ -80,7 +149,11 @@ func (b *querymanBulk) AddBatch(params ...interface{}) (err error)	{
 		return b.addList(val)
 	case reflect.Struct :
 		if _, is := val.(driver.Valuer); !is {
-			return b.addWithObject(val)
+			err = b.addWithObject(val)
+			if err == nil {
+				b.markTarget(params[0])
+			}
+			return
 		}
 	case reflect.Map :
 		return b.addMap(val)
@@ -89,32 +162,384 @@ func (b *querymanBulk) AddBatch(params ...interface{}) (err error)	{
 	return b.addWithList(params)
 }
 
-func (b *querymanBulk) Execute() (sql.Result, error)	{
+func (b *querymanBulk) ExecuteAndReset() (sql.Result, error)	{
+	defer b.Reset()
+	return b.Execute()
+}
+
+func (b *querymanBulk) Reset()	{
+	b.truncate(0)
+}
+
+func (b *querymanBulk) Len() int	{
+	return b.execCount
+}
+
+func (b *querymanBulk) EstimatedSize() int	{
+	size := 0
+	for _, v := range b.rowBytes {
+		size += v
+	}
+	return size
+}
+
+// truncate keeps the first rows
+func (b *querymanBulk) truncate(rows int)	{
+	if rows >= b.execCount {
+		return
+	}
+	offset := 0
+	for _, size := range b.rowSizes[:rows] {
+		offset += size
+	}
+	b.params = b.params[:offset]
+	b.rowSizes = b.rowSizes[:rows]
+	b.rowBytes = b.rowBytes[:rows]
+	b.targets = b.targets[:rows]
+	b.execCount = rows
+}
+
+// Execute clears rows when it succeeds. rows are kept on failure so that they can be executed again
+func (b *querymanBulk) Execute() (result sql.Result, err error)	{
 	if b.stmt.eleType == eleTypeInsert	{
-		return b.executeInsert()
+		result, err = b.executeInsert()
 	} else if b.stmt.eleType == eleTypeUpdate	{
-		return b.executeUpdate()
+		result, err = b.executeUpdate()
+	} else {
+		return nil, fmt.Errorf("only support insert/update")
 	}
 
-	return nil, fmt.Errorf("only support insert/update")
+	if err == nil {
+		b.Reset()
+	}
+	return result, err
 }
 
 func (b *querymanBulk) executeInsert() (sql.Result, error)	{
-	bulkInsertQuery := findValuesClauseInInsert(b.stmt.Query)
-	sql := bulkInsertQuery.buildMultiValueQuery(b.execCount)
-	return b.sqlProxy.exec(sql, b.params...)
+	dialect := b.stmt.getNormalizer().getDialect()
+	if dialect.BindStyle() == BindNamed {
+		return nil, fmt.Errorf("bulk insert is not supported for %s", dialect.Name())
+	}
+
+	if b.opts.Mode == BulkModeNative {
+		result, ok, err := b.executeNative(dialect)
+		if ok {
+			return result, err
+		}
+		if b.sqlProxy.debugEnabled() {
+			b.sqlProxy.debugPrint("bulk [%s] : native load is not available. multi row insert is used", b.stmt.Id)
+		}
+	}
+
+	query := b.stmt.Query
+	if len(b.stmt.HoldedQuery) > 0 {
+		// build from holded query so that numbered placeholders are resolved in sequence
+		query = b.stmt.HoldedQuery
+	}
+	bulkInsertQuery, err := parseBulkInsertQuery(dialect, query)
+	if err != nil {
+		return nil, fmt.Errorf("bulk [%s] : %s", b.stmt.Id, err.Error())
+	}
+
+	// rows of upsert or insert ignoring conflict get no new id. so ids can't be derived from first id
+	deriveIds := b.stmt.collectInsertId() && !b.stmt.upsert && !hasConflictClause(dialect, query)
+	step := int64(1)
+	if deriveIds {
+		step, err = b.insertIdStep(dialect)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	chunks := b.splitChunks(dialect, bulkInsertQuery)
+	result := ExecMultiResult{}
+	err = b.runChunks(chunks, func(sqlProxy SqlProxy, query string, rows int, params []interface{}) error {
+		chunkResult, err := execStatement(sqlProxy, b.stmt, query, params...)
+		if err != nil {
+			return err
+		}
+		result.chunkRows = append(result.chunkRows, int64(rows))
+		if b.stmt.returning {
+			returned := chunkResult.(ExecMultiResult)
+			if len(returned.idList) > 0 {
+				result.chunkIdList = append(result.chunkIdList, returned.idList[0])
+			}
+			result.merge(returned)
+			return nil
+		}
+
+		affected, _ := chunkResult.RowsAffected()
+		result.rowAffected += affected
+		if deriveIds {
+			id, err := chunkResult.LastInsertId()
+			if err != nil {
+				return fmt.Errorf("fail to get last inserted id : %w", err)
+			}
+			first := dialect.MultiRowInsertId(id, int64(rows))
+			result.chunkIdList = append(result.chunkIdList, first)
+			for i := int64(0); i < int64(rows); i++ {
+				result.addInsertId(first + i*step)
+			}
+		}
+		return nil
+	}, bulkInsertQuery)
+	if err != nil && b.opts.InTransaction {
+		return nil, err
+	}
+
+	// conflicting rows return no key, so keys are written only when every row has its key
+	if len(b.stmt.KeyProperty) > 0 && (deriveIds || b.stmt.returning) && len(result.idList) == b.execCount {
+		if keyErr := b.writeKeys(result.idList); keyErr != nil && err == nil {
+			err = keyErr
+		}
+	}
+	// without transaction, result reports chunks executed before failure
+	return result, err
+}
+
+// insertIdStep is the increment between generated ids of a multi row insert
+func (b *querymanBulk) insertIdStep(dialect Dialect) (int64, error) {
+	if b.opts.InsertIdStep > 0 {
+		return b.opts.InsertIdStep, nil
+	}
+	query := dialect.InsertIdStepQuery()
+	if len(query) == 0 {
+		return 1, nil
+	}
+
+	source := proxyDatabase(b.sqlProxy)
+	if step, ok := insertIdSteps.load(source); ok {
+		return step, nil
+	}
+
+	step := int64(1)
+	err := b.sqlProxy.queryRow(query).Scan(&step)
+	if err != nil {
+		return 0, fmt.Errorf("fail to get insert id step : %w", err)
+	}
+	if step < 1 {
+		step = 1
+	}
+	insertIdSteps.store(source, step)
+	return step, nil
+}
+
+// insertIdStepCache keeps insert id step per connection pool. it is removed when QueryMan is closed
+type insertIdStepCache struct {
+	sync.RWMutex
+	steps map[*sql.DB]int64
+}
+
+var insertIdSteps = &insertIdStepCache{steps: make(map[*sql.DB]int64)}
+
+func (c *insertIdStepCache) load(db *sql.DB) (int64, bool) {
+	if db == nil {
+		return 0, false
+	}
+	c.RLock()
+	defer c.RUnlock()
+	step, ok := c.steps[db]
+	return step, ok
+}
+
+func (c *insertIdStepCache) store(db *sql.DB, step int64) {
+	if db == nil {
+		return
+	}
+	c.Lock()
+	defer c.Unlock()
+	c.steps[db] = step
+}
+
+func (c *insertIdStepCache) remove(db *sql.DB) {
+	c.Lock()
+	defer c.Unlock()
+	delete(c.steps, db)
+}
+
+// writeKeys sets generated ids to keyProperty of struct pointers passed to AddBatch
+func (b *querymanBulk) writeKeys(idList []int64) error {
+	for i, target := range b.targets {
+		if target == nil || i >= len(idList) {
+			continue
+		}
+		field := reflect.ValueOf(target).Elem().FieldByName(b.stmt.KeyProperty)
+		if !field.IsValid() || !field.CanSet() {
+			return fmt.Errorf("keyProperty %s is not found in %s", b.stmt.KeyProperty, reflect.TypeOf(target).Elem().String())
+		}
+		switch field.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64 :
+			field.SetInt(idList[i])
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64 :
+			field.SetUint(uint64(idList[i]))
+		default :
+			return fmt.Errorf("keyProperty %s should be integer type : %s", b.stmt.KeyProperty, field.Kind().String())
+		}
+	}
+	return nil
+}
+
+// bulkChunk is a range of rows executed as one multi row insert
+type bulkChunk struct {
+	start int
+	end   int
 }
 
+// splitChunks splits rows so that each chunk is within placeholder limit and estimated size
+func (b *querymanBulk) splitChunks(dialect Dialect, query BulkInsertQuery) []bulkChunk {
+	maxPlaceholders := b.opts.MaxPlaceholders
+	if maxPlaceholders <= 0 {
+		maxPlaceholders = dialect.MaxPlaceholders()
+	}
+	maxBytes := b.opts.MaxBytes
+	baseBytes := len(query.prefix) + len(query.suffix) + 2
+
+	chunks := make([]bulkChunk, 0)
+	chunk := bulkChunk{}
+	placeholders := 0
+	bytes := baseBytes
+	for i, size := range b.rowSizes {
+		rowBytes := len(query.values) + 1 + b.rowBytes[i]
+
+		exceeded := placeholders+size > maxPlaceholders || (maxBytes > 0 && bytes+rowBytes > maxBytes)
+		if exceeded && chunk.end > chunk.start {
+			chunks = append(chunks, chunk)
+			chunk = bulkChunk{start: i, end: i}
+			placeholders = 0
+			bytes = baseBytes
+		}
+		chunk.end = i + 1
+		placeholders += size
+		bytes += rowBytes
+	}
+	if chunk.end > chunk.start {
+		chunks = append(chunks, chunk)
+	}
+	return chunks
+}
+
+// runChunks executes chunks in sequence. chunks run in a transaction when InTransaction is set
+func (b *querymanBulk) runChunks(chunks []bulkChunk, exec func(sqlProxy SqlProxy, query string, rows int, params []interface{}) error, query BulkInsertQuery) error {
+	offsets := make([]int, len(b.rowSizes)+1)
+	for i, size := range b.rowSizes {
+		offsets[i+1] = offsets[i] + size
+	}
+
+	run := func(sqlProxy SqlProxy) error {
+		for i, chunk := range chunks {
+			rows := chunk.end - chunk.start
+			chunkQuery := query.buildMultiValueQuery(rows)
+			if len(b.stmt.HoldedQuery) > 0 {
+				chunkQuery = b.stmt.resolveHolding(chunkQuery, nil)
+			}
+			if sqlProxy.debugEnabled() && len(chunks) > 1 {
+				sqlProxy.debugPrint("bulk insert [%s] : chunk %d/%d, %d rows", b.stmt.Id, i+1, len(chunks), rows)
+			}
+
+			err := exec(sqlProxy, chunkQuery, rows, b.params[offsets[chunk.start]:offsets[chunk.end]])
+			if err != nil {
+				if len(chunks) > 1 {
+					return fmt.Errorf("fail to execute chunk %d/%d : %w", i+1, len(chunks), err)
+				}
+				return err
+			}
+		}
+		return nil
+	}
+
+	if b.opts.InTransaction && len(chunks) > 1 {
+		return runInBatch(b.sqlProxy, run)
+	}
+	return run(b.sqlProxy)
+}
+
+// estimateParamSize estimates bytes of parameter sent to server
+func estimateParamSize(v interface{}) int {
+	switch p := v.(type) {
+	case nil :
+		return 4
+	case string :
+		return len(p) + 2
+	case []byte :
+		return len(p) + 2
+	case time.Time :
+		return 28
+	case bool, int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64 :
+		return 20
+	default :
+		return len(fmt.Sprint(v)) + 2
+	}
+}
+
+// executeUpdate executes update or delete statement with one prepared statement per row in a transaction.
+// result is ExecMultiResult which reports affected count of each row
+// executeUpdate runs one prepared statement per row in a transaction on every dialect.
+// rewriting rows into one statement (CASE WHEN or VALUES join) loses affected count of each row
 func (b *querymanBulk) executeUpdate()	(sql.Result, error) {
+	result := ExecMultiResult{}
+	err := runInBatch(b.sqlProxy, func(sqlProxy SqlProxy) error {
+		pstmt, err := sqlProxy.prepare(b.stmt.Query)
+		if err != nil {
+			return err
+		}
+		defer pstmt.Close()
+
+		offset := 0
+		for _, size := range b.rowSizes {
+			err = execPrepared(pstmt, b.stmt, &result, b.params[offset:offset+size]...)
+			if err != nil {
+				return err
+			}
+			offset += size
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
 
-	return nil, fmt.Errorf("not support yet (bulk update)")
+	if b.sqlProxy.debugEnabled() {
+		b.sqlProxy.debugPrint("bulk update [%s] : %d rows", b.stmt.Id, b.execCount)
+	}
+	return result, nil
+}
+
+// batchRunner runs statements of fn in one transaction
+type batchRunner interface {
+	runInBatch(fn func(sqlProxy SqlProxy) error) error
+}
+
+func runInBatch(sqlProxy SqlProxy, fn func(sqlProxy SqlProxy) error) error {
+	if runner, ok := sqlProxy.(batchRunner); ok {
+		return runner.runInBatch(fn)
+	}
+	return fn(sqlProxy)
 }
 
-func (b *querymanBulk) addParams(param ...interface{})	{
+// addParams adds a row. every row should bind all columns of statement.
+// user query with raw placeholders has no column mention, so its rows are checked by driver
+func (b *querymanBulk) addParams(param ...interface{}) error	{
+	if len(b.stmt.columnMention) > 0 && len(param) != len(b.stmt.columnMention) {
+		return fmt.Errorf("binding parameter count mismatch. defined=%d, row[%d]=%d", len(b.stmt.columnMention), b.execCount, len(param))
+	}
+
+	bytes := 0
 	for _, p := range param {
 		b.params = append(b.params, p)
+		bytes += estimateParamSize(p)
 	}
+	b.rowSizes = append(b.rowSizes, len(param))
+	b.rowBytes = append(b.rowBytes, bytes)
+	b.targets = append(b.targets, nil)
 	b.execCount = b.execCount + 1
+	return nil
+}
+
+// markTarget keeps struct pointer of the last row for keyProperty
+func (b *querymanBulk) markTarget(v interface{}) {
+	if reflect.TypeOf(v).Kind() == reflect.Ptr {
+		b.targets[len(b.targets)-1] = v
+	}
 }
 
 func (b *querymanBulk) addList(val interface{}) error {
@@ -139,18 +564,12 @@ func (b *querymanBulk) addWithObject(parameter interface{}) error {
 }
 
 func (b *querymanBulk) addWithMap(m map[string]interface{}) error {
-	passing := make([]interface{}, 0)
-	for _,v := range b.stmt.columnMention {
-		found, ok := m[v.Name()]
-		if !ok {
-			return fmt.Errorf("addWithMap : not found \"%s\" from parameter values", v)
-		}
-		passing = append(passing, found)
+	passing, err := b.stmt.bindMap(m)
+	if err != nil {
+		return err
 	}
 
-	b.addParams(passing...)
-
-	return nil
+	return b.addParams(passing...)
 }
 
 func (b *querymanBulk) addWithList(args []interface{}) error {
@@ -179,11 +598,12 @@ func (b *querymanBulk) addWithList(args []interface{}) error {
 		return b.addWithNestedMap(args)
 	}
 
-	if len(b.stmt.columnMention) > len(args) {
-		return fmt.Errorf("binding parameter count mismatch. defined=%d, args=%d", len(b.stmt.columnMention), len(args))
+	passing, err := b.stmt.fillMissingArgs(args)
+	if err != nil {
+		return err
 	}
 
-	return nil
+	return b.addParams(passing...)
 }
 
 func (b *querymanBulk) addWithNestedList(args []interface{}) error {
@@ -192,21 +612,26 @@ func (b *querymanBulk) addWithNestedList(args []interface{}) error {
 		if reflect.TypeOf(v).Kind() != reflect.Slice && reflect.TypeOf(v).Kind() != reflect.Array {
 			return fmt.Errorf("nested listing structure should have slice type data only. %d=%s", i, reflect.TypeOf(v).String())
 		}
-		if len(b.stmt.columnMention) > reflect.ValueOf(v).Len() {
+		if !b.stmt.acceptArgCount(reflect.ValueOf(v).Len()) {
 			return fmt.Errorf("binding parameter count mismatch. defined=%d, args[%d]=%d", len(b.stmt.columnMention), i, reflect.ValueOf(v).Len())
 		}
 	}
 
 	for _, v := range args {
-		passing := flattenToList(v)
-		b.addParams(passing...)
+		passing, err := b.stmt.fillMissingArgs(flattenToList(v))
+		if err != nil {
+			return err
+		}
+		if err = b.addParams(passing...); err != nil {
+			return err
+		}
 	}
 
 	return nil
 }
 
 func (b *querymanBulk) addWithStructList(args []interface{}) error {
-	for _, v := range args {
+	for i, v := range args {
 		atype := reflect.TypeOf(v)
 		val := v
 
@@ -218,18 +643,18 @@ func (b *querymanBulk) addWithStructList(args []interface{}) error {
 			}
 			val = reflect.ValueOf(v).Elem().Interface()
 		}
+		if atype.Kind() != reflect.Struct {
+			return fmt.Errorf("struct listing structure should have struct type data only. %d=%s", i, atype.String())
+		}
 
-		m := flattenStructToMap(val)
-		passing := make([]interface{}, 0)
-
-		for _,v := range b.stmt.columnMention {
-			found, ok := m[v.Name()]
-			if !ok {
-				return fmt.Errorf("addWithStructList : not found \"%s\" from parameter values", v)
-			}
-			passing = append(passing, found)
+		passing, err := b.stmt.bindMap(flattenStructToMap(val))
+		if err != nil {
+			return err
 		}
-		b.addParams(passing...)
+		if err = b.addParams(passing...); err != nil {
+			return err
+		}
+		b.markTarget(v)
 	}
 
 	return nil
@@ -241,8 +666,8 @@ func (b *querymanBulk) addWithNestedMap(args []interface{}) error {
 		if reflect.TypeOf(v).Kind() != reflect.Map {
 			return fmt.Errorf("nested listing structure should have map type data only. %d=%s", i, reflect.TypeOf(v).String())
 		}
-		if len(b.stmt.columnMention) > reflect.ValueOf(v).Len() {
-			return fmt.Errorf("binding parameter count mismatch. defined=%d, args[%d]=%d", len(b.stmt.columnMention), i, reflect.ValueOf(v).Len())
+		if b.stmt.requiredBindCount() > reflect.ValueOf(v).Len() {
+			return fmt.Errorf("binding parameter count mismatch. defined=%d, args[%d]=%d", b.stmt.requiredBindCount(), i, reflect.ValueOf(v).Len())
 		}
 	}
 
@@ -252,38 +677,20 @@ func (b *querymanBulk) addWithNestedMap(args []interface{}) error {
 			return ErrInvalidMapType
 		}
 
-		passing := make([]interface{}, 0)
-		for _,v2 := range b.stmt.columnMention {
-			found, ok := m[v2.Name()]
-			if !ok {
-				return fmt.Errorf("not found \"%s\" from map", v)
-			}
-			passing = append(passing, found)
+		passing, err := b.stmt.bindMap(m)
+		if err != nil {
+			return err
 		}
 
-		b.addParams(passing...)
+		if err = b.addParams(passing...); err != nil {
+			return err
+		}
 	}
 
 	return nil
 }
 
 
-func findValuesClauseInInsert(sql string) BulkInsertQuery	{
-	str := strings.ToLower(sql)
-	v := strings.Index(str, "values")
-	left := strings.Index(str[v+6:], "(")
-	start := v + left + 6
-	right := strings.Index(str[start:], ")")
-	right = right + 1
-
-	bulk := BulkInsertQuery{}
-	bulk.prefix = sql[:start]
-	bulk.values = sql[start:start+right]
-	bulk.suffix = sql[start+right:]
-	return bulk
-}
-
-
 type BulkInsertQuery struct {
 	prefix 	string
 	values 	string
@@ -0,0 +1,107 @@
+//
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+//
+// @project queryman
+// @author agent
+// @date 2026. 10. 18. PM 11:10
+//
+
+// queryman command line tool
+//
+//	queryman gen -pkg dao -out dao/query_gen.go query/*.xml
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"throosea.com/queryman"
+)
+
+type importList []string
+
+func (i *importList) String() string {
+	return strings.Join(*i, ",")
+}
+
+func (i *importList) Set(value string) error {
+	*i = append(*i, value)
+	return nil
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+
+	switch os.Args[1] {
+	case "gen":
+		if err := generate(os.Args[2:]); err != nil {
+			fmt.Fprintf(os.Stderr, "queryman gen : %s\n", err.Error())
+			os.Exit(1)
+		}
+	default:
+		usage()
+		os.Exit(2)
+	}
+}
+
+func usage() {
+	fmt.Fprintf(os.Stderr, "usage : queryman gen [-pkg name] [-driver name] [-import path] [-out file] xmlfile...\n")
+}
+
+func generate(args []string) error {
+	var imports importList
+
+	flags := flag.NewFlagSet("gen", flag.ExitOnError)
+	pkg := flags.String("pkg", "main", "package name of generated file")
+	driver := flags.String("driver", "mysql", "database driver name")
+	out := flags.String("out", "", "output file (default stdout)")
+	flags.Var(&imports, "import", "additional import path for paramType/resultType (repeatable)")
+	flags.Parse(args)
+
+	if flags.NArg() == 0 {
+		usage()
+		return fmt.Errorf("no xml file")
+	}
+
+	generator := queryman.NewCodeGenerator(*pkg)
+	generator.DriverName = *driver
+	generator.Imports = imports
+	for _, file := range flags.Args() {
+		if err := generator.AddFile(file); err != nil {
+			return err
+		}
+	}
+
+	var buffer bytes.Buffer
+	if err := generator.Generate(&buffer); err != nil {
+		return err
+	}
+
+	if len(*out) == 0 {
+		_, err := os.Stdout.Write(buffer.Bytes())
+		return err
+	}
+	return ioutil.WriteFile(*out, buffer.Bytes(), 0644)
+}
@@ -0,0 +1,186 @@
+//
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+//
+// @project queryman
+// @author agent
+// @date 2026. 10. 18. PM 11:57
+//
+
+package queryman
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+)
+
+type txContextKey struct{}
+
+// sqlConn is implemented by both *sql.DB and *sql.Tx
+type sqlConn interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+	PrepareContext(ctx context.Context, query string) (*sql.Stmt, error)
+}
+
+// contextProxy runs statements on conn with ctx. source is the database of conn
+type contextProxy struct {
+	SqlDebugger
+	conn        sqlConn
+	ctx         context.Context
+	transaction bool
+	source      *sql.DB
+}
+
+func (p contextProxy) exec(query string, args ...interface{}) (sql.Result, error) {
+	return p.conn.ExecContext(p.ctx, query, args...)
+}
+
+func (p contextProxy) query(query string, args ...interface{}) (*sql.Rows, error) {
+	return p.conn.QueryContext(p.ctx, query, args...)
+}
+
+func (p contextProxy) queryRow(query string, args ...interface{}) *sql.Row {
+	return p.conn.QueryRowContext(p.ctx, query, args...)
+}
+
+func (p contextProxy) prepare(query string) (*sql.Stmt, error) {
+	return p.conn.PrepareContext(p.ctx, query)
+}
+
+func (p contextProxy) isTransaction() bool {
+	return p.transaction
+}
+
+func (p contextProxy) driver() driver.Driver {
+	if p.source == nil {
+		return nil
+	}
+	return p.source.Driver()
+}
+
+// databaseProxy reports database of connection which proxy runs on
+type databaseProxy interface {
+	database() *sql.DB
+}
+
+func (p contextProxy) database() *sql.DB {
+	return p.source
+}
+
+// proxyDatabase returns database of sqlProxy. it is nil when unknown
+func proxyDatabase(sqlProxy SqlProxy) *sql.DB {
+	if p, ok := sqlProxy.(databaseProxy); ok {
+		return p.database()
+	}
+	return nil
+}
+
+// WithTx returns context carrying tx. context aware methods of QueryMan join the transaction
+func (man *QueryMan) WithTx(ctx context.Context, tx *DBTransaction) context.Context {
+	return context.WithValue(ctx, txContextKey{}, tx)
+}
+
+// TxFromContext returns transaction carried by ctx
+func TxFromContext(ctx context.Context) (*DBTransaction, bool) {
+	tx, ok := ctx.Value(txContextKey{}).(*DBTransaction)
+	return tx, ok && tx != nil
+}
+
+// joinedTx returns transaction of ctx when it is opened on datasource of man
+func (man *QueryMan) joinedTx(ctx context.Context) *DBTransaction {
+	tx, ok := TxFromContext(ctx)
+	if !ok || tx.source != man.db {
+		return nil
+	}
+	return tx
+}
+
+func (man *QueryMan) primary(ctx context.Context) SqlProxy {
+	return contextProxy{man, man.db, ctx, false, man.db}
+}
+
+func (t *DBTransaction) proxy(ctx context.Context) SqlProxy {
+	if t.tx == nil {
+		return contextProxy{t, t.source, ctx, false, t.source}
+	}
+	return contextProxy{t, t.tx, t.statementContext(ctx), true, t.source}
+}
+
+// statementContext derives context of statement from ctx of transaction
+// so that running statement is cancelled when transaction is timed out or cancelled
+func (t *DBTransaction) statementContext(ctx context.Context) context.Context {
+	if t.ctx == nil || t.ctx.Done() == nil || ctx.Done() == t.ctx.Done() {
+		return ctx
+	}
+	if ctx.Done() == nil {
+		return valueContext{t.ctx, ctx}
+	}
+
+	stmtCtx, cancel := context.WithCancel(ctx)
+	go func() {
+		select {
+		case <-t.ctx.Done() :
+			cancel()
+		case <-stmtCtx.Done() :
+		}
+	}()
+	return stmtCtx
+}
+
+// valueContext takes cancellation from Context and values from values
+type valueContext struct {
+	context.Context
+	values	context.Context
+}
+
+func (c valueContext) Value(key interface{}) interface{}	{
+	return c.values.Value(key)
+}
+
+// Context returns context carrying the transaction. context of non transactional executor carries no transaction
+func (t *DBTransaction) Context() context.Context {
+	ctx := t.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if t.tx == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, txContextKey{}, t)
+}
+
+// runInBatch runs fn in a transaction. proxy of transaction runs fn as it is
+func (p contextProxy) runInBatch(fn func(sqlProxy SqlProxy) error) error {
+	db, ok := p.conn.(*sql.DB)
+	if !ok {
+		return fn(p)
+	}
+
+	tx, err := db.BeginTx(p.ctx, nil)
+	if err != nil {
+		return err
+	}
+	err = fn(contextProxy{p.SqlDebugger, tx, p.ctx, true, db})
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
@@ -0,0 +1,538 @@
+//
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+//
+// @project queryman
+// @author agent
+// @date 2026. 10. 18. PM 11:27
+//
+package queryman
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+)
+
+const (
+	BindPositional = iota
+	BindNamed
+)
+
+// BindStyle tells how arguments are passed to the driver
+type BindStyle uint8
+
+func (b BindStyle) String() string {
+	switch b {
+	case BindPositional :	return "POSITIONAL"
+	case BindNamed :	return "NAMED"
+	}
+	return "UNKNOWN"
+}
+
+/*
+MySQL/SQLite        PostgreSQL            SQL Server            Oracle
+============        ==========            ==========            ======
+WHERE col = ?       WHERE col = $1        WHERE col = @p1       WHERE col = :Col
+VALUES(?, ?, ?)     VALUES($1, $2, $3)    VALUES(@p1,@p2,@p3)   VALUES(:A, :B, :C)
+*/
+
+// Dialect describes database specific behavior of sql statement
+type Dialect interface {
+	Name() string
+	BindStyle() BindStyle
+	// Placeholder returns bind mark of index (starts from 1) and bind name
+	Placeholder(index int, name string) string
+	// NamedArg builds argument for named binding
+	NamedArg(name string, value interface{}) interface{}
+	QuoteIdentifier(name string) string
+	Paginate(query string, offset int, limit int) string
+	BackslashEscape() bool
+	DollarQuote() bool
+	SupportLastInsertId() bool
+	// ReturningClause is keyword of insert clause which returns generated keys as rows (RETURNING, OUTPUT).
+	// empty string means generated keys are not returned as rows
+	ReturningClause() string
+	// MultiRowInsertId converts LastInsertId of multi row insert into the first generated id
+	MultiRowInsertId(lastInsertId int64, rows int64) int64
+	// Savepoint builds savepoint sql. empty string means the action is not needed
+	Savepoint(action SavepointAction, name string) string
+	// MaxPlaceholders is the limit of placeholders in a statement
+	MaxPlaceholders() int
+	// InsertIdStepQuery selects increment of generated ids. empty string means 1
+	InsertIdStepQuery() string
+	// Upsert builds insert which updates updateColumns when a row of conflictKey exists
+	Upsert(table string, columns []string, values []string, conflictKey []string, updateColumns []string) string
+}
+
+type SavepointAction int
+
+const (
+	SavepointCreate SavepointAction = iota
+	SavepointRollback
+	SavepointRelease
+)
+
+func findDialect(driverName string) Dialect {
+	dialect, ok := lookupDialect(driverName)
+	if !ok {
+		return mysqlDialect{}
+	}
+	return dialect
+}
+
+// lookupDialect finds dialect of driver or dialect name. ok is false for unknown name
+func lookupDialect(name string) (Dialect, bool) {
+	switch(strings.ToLower(name)) {
+	case "mysql", "mariadb" :
+		return mysqlDialect{}, true
+	case "postgres", "postgresql", "pgx", "cloudsqlpostgres" :
+		return postgresDialect{}, true
+	case "oci8", "godror", "goracle", "oracle" :
+		return oracleDialect{}, true
+	case "sqlite3", "sqlite" :
+		return sqliteDialect{}, true
+	case "sqlserver", "mssql", "azuresql" :
+		return sqlserverDialect{}, true
+	default :
+		return nil, false
+	}
+}
+
+// databaseIdName returns dialect name of databaseId. empty databaseId means generic statement
+func databaseIdName(databaseId string) (string, error) {
+	if len(databaseId) == 0 {
+		return "", nil
+	}
+	dialect, ok := lookupDialect(databaseId)
+	if !ok {
+		return "", fmt.Errorf("unknown databaseId : %s", databaseId)
+	}
+	return dialect.Name(), nil
+}
+
+// selectVariants picks statement variant for the dialect per id.
+// variant declared with matching databaseId wins over generic one, others are dropped
+func selectVariants(list []QueryStatement, dialect Dialect) ([]QueryStatement, error) {
+	selected := make([]QueryStatement, 0, len(list))
+	position := make(map[string]int)
+	for _, v := range list {
+		name, err := databaseIdName(v.DatabaseId)
+		if err != nil {
+			return nil, fmt.Errorf("stmt [%s] : %s", v.Id, err.Error())
+		}
+		if len(name) > 0 && name != dialect.Name() {
+			continue
+		}
+
+		id := strings.ToUpper(v.Id)
+		index, exists := position[id]
+		if !exists {
+			position[id] = len(selected)
+			selected = append(selected, v)
+			continue
+		}
+		if len(name) > 0 {
+			selected[index] = v
+		}
+	}
+	return selected, nil
+}
+
+// quoteIdentifier quotes each part of dotted name and doubles closing quote in it
+func quoteIdentifier(name string, open string, close string) string {
+	parts := strings.Split(name, ".")
+	for i, v := range parts {
+		parts[i] = open + strings.Replace(v, close, close+close, -1) + close
+	}
+	return strings.Join(parts, ".")
+}
+
+func paginateWithLimit(query string, offset int, limit int) string {
+	if offset > 0 {
+		return fmt.Sprintf("%s LIMIT %d OFFSET %d", query, limit, offset)
+	}
+	return fmt.Sprintf("%s LIMIT %d", query, limit)
+}
+
+// savepointSql builds ANSI savepoint sql. releaseSupported is false when savepoint is released at commit only
+func savepointSql(action SavepointAction, name string, releaseSupported bool) string {
+	switch action {
+	case SavepointCreate :
+		return "SAVEPOINT " + name
+	case SavepointRollback :
+		return "ROLLBACK TO SAVEPOINT " + name
+	default :
+		if !releaseSupported {
+			return ""
+		}
+		return "RELEASE SAVEPOINT " + name
+	}
+}
+
+// paginateWithFetch uses ANSI OFFSET/FETCH clause which requires ORDER BY on SQL Server
+func paginateWithFetch(dialect Dialect, query string, offset int, limit int) string {
+	if !newDialectLexer(dialect, query).containsKeyword(keywordOrder) {
+		query = query + " ORDER BY (SELECT NULL)"
+	}
+	return fmt.Sprintf("%s OFFSET %d ROWS FETCH NEXT %d ROWS ONLY", query, offset, limit)
+}
+
+type mysqlDialect struct {
+}
+
+func (d mysqlDialect) Name() string {
+	return "mysql"
+}
+
+func (d mysqlDialect) BindStyle() BindStyle {
+	return BindPositional
+}
+
+func (d mysqlDialect) Placeholder(index int, name string) string {
+	return "?"
+}
+
+func (d mysqlDialect) NamedArg(name string, value interface{}) interface{} {
+	return value
+}
+
+func (d mysqlDialect) QuoteIdentifier(name string) string {
+	return quoteIdentifier(name, "`", "`")
+}
+
+func (d mysqlDialect) Paginate(query string, offset int, limit int) string {
+	return paginateWithLimit(query, offset, limit)
+}
+
+func (d mysqlDialect) BackslashEscape() bool {
+	return true
+}
+
+func (d mysqlDialect) DollarQuote() bool {
+	return false
+}
+
+func (d mysqlDialect) SupportLastInsertId() bool {
+	return true
+}
+
+func (d mysqlDialect) ReturningClause() string {
+	return ""
+}
+
+func (d mysqlDialect) MultiRowInsertId(lastInsertId int64, rows int64) int64 {
+	return lastInsertId
+}
+
+func (d mysqlDialect) Savepoint(action SavepointAction, name string) string {
+	return savepointSql(action, name, true)
+}
+
+func (d mysqlDialect) MaxPlaceholders() int {
+	return 65535
+}
+
+func (d mysqlDialect) InsertIdStepQuery() string {
+	return "SELECT @@auto_increment_increment"
+}
+
+func (d mysqlDialect) Upsert(table string, columns []string, values []string, conflictKey []string, updateColumns []string) string {
+	return upsertOnDuplicateKey(table, columns, values, conflictKey, updateColumns)
+}
+
+// postgresDialect numbers placeholders from $1.
+// generated keys are fetched with RETURNING clause because LastInsertId is not supported
+type postgresDialect struct {
+}
+
+func (d postgresDialect) Name() string {
+	return "postgres"
+}
+
+func (d postgresDialect) BindStyle() BindStyle {
+	return BindPositional
+}
+
+func (d postgresDialect) Placeholder(index int, name string) string {
+	return fmt.Sprintf("$%d", index)
+}
+
+func (d postgresDialect) NamedArg(name string, value interface{}) interface{} {
+	return value
+}
+
+func (d postgresDialect) QuoteIdentifier(name string) string {
+	return quoteIdentifier(name, `"`, `"`)
+}
+
+func (d postgresDialect) Paginate(query string, offset int, limit int) string {
+	return paginateWithLimit(query, offset, limit)
+}
+
+func (d postgresDialect) BackslashEscape() bool {
+	return false
+}
+
+func (d postgresDialect) DollarQuote() bool {
+	return true
+}
+
+func (d postgresDialect) SupportLastInsertId() bool {
+	return false
+}
+
+func (d postgresDialect) ReturningClause() string {
+	return keywordReturning
+}
+
+func (d postgresDialect) MultiRowInsertId(lastInsertId int64, rows int64) int64 {
+	return lastInsertId
+}
+
+func (d postgresDialect) Savepoint(action SavepointAction, name string) string {
+	return savepointSql(action, name, true)
+}
+
+func (d postgresDialect) MaxPlaceholders() int {
+	return 65535
+}
+
+func (d postgresDialect) InsertIdStepQuery() string {
+	return ""
+}
+
+func (d postgresDialect) Upsert(table string, columns []string, values []string, conflictKey []string, updateColumns []string) string {
+	return upsertOnConflict(table, columns, values, conflictKey, updateColumns)
+}
+
+// sqliteDialect reports LastInsertId of multi row insert as the last row
+type sqliteDialect struct {
+}
+
+func (d sqliteDialect) Name() string {
+	return "sqlite"
+}
+
+func (d sqliteDialect) BindStyle() BindStyle {
+	return BindPositional
+}
+
+func (d sqliteDialect) Placeholder(index int, name string) string {
+	return "?"
+}
+
+func (d sqliteDialect) NamedArg(name string, value interface{}) interface{} {
+	return value
+}
+
+func (d sqliteDialect) QuoteIdentifier(name string) string {
+	return quoteIdentifier(name, `"`, `"`)
+}
+
+func (d sqliteDialect) Paginate(query string, offset int, limit int) string {
+	return paginateWithLimit(query, offset, limit)
+}
+
+func (d sqliteDialect) BackslashEscape() bool {
+	return false
+}
+
+func (d sqliteDialect) DollarQuote() bool {
+	return false
+}
+
+func (d sqliteDialect) SupportLastInsertId() bool {
+	return true
+}
+
+func (d sqliteDialect) ReturningClause() string {
+	return keywordReturning
+}
+
+func (d sqliteDialect) MultiRowInsertId(lastInsertId int64, rows int64) int64 {
+	if rows < 2 {
+		return lastInsertId
+	}
+	return lastInsertId - rows + 1
+}
+
+func (d sqliteDialect) Savepoint(action SavepointAction, name string) string {
+	return savepointSql(action, name, true)
+}
+
+func (d sqliteDialect) MaxPlaceholders() int {
+	return 32766
+}
+
+func (d sqliteDialect) InsertIdStepQuery() string {
+	return ""
+}
+
+func (d sqliteDialect) Upsert(table string, columns []string, values []string, conflictKey []string, updateColumns []string) string {
+	return upsertOnConflict(table, columns, values, conflictKey, updateColumns)
+}
+
+// sqlserverDialect numbers placeholders from @p1 (github.com/denisenkom/go-mssqldb).
+// LastInsertId is not supported. declare OUTPUT INSERTED.id in the query for generated key,
+// rows of OUTPUT are collected as generated keys
+type sqlserverDialect struct {
+}
+
+func (d sqlserverDialect) Name() string {
+	return "sqlserver"
+}
+
+func (d sqlserverDialect) BindStyle() BindStyle {
+	return BindPositional
+}
+
+func (d sqlserverDialect) Placeholder(index int, name string) string {
+	return fmt.Sprintf("@p%d", index)
+}
+
+func (d sqlserverDialect) NamedArg(name string, value interface{}) interface{} {
+	return value
+}
+
+func (d sqlserverDialect) QuoteIdentifier(name string) string {
+	return quoteIdentifier(name, "[", "]")
+}
+
+func (d sqlserverDialect) Paginate(query string, offset int, limit int) string {
+	return paginateWithFetch(d, query, offset, limit)
+}
+
+func (d sqlserverDialect) BackslashEscape() bool {
+	return false
+}
+
+func (d sqlserverDialect) DollarQuote() bool {
+	return false
+}
+
+func (d sqlserverDialect) SupportLastInsertId() bool {
+	return false
+}
+
+func (d sqlserverDialect) ReturningClause() string {
+	return keywordOutput
+}
+
+func (d sqlserverDialect) MultiRowInsertId(lastInsertId int64, rows int64) int64 {
+	return lastInsertId
+}
+
+func (d sqlserverDialect) Savepoint(action SavepointAction, name string) string {
+	switch action {
+	case SavepointCreate :
+		return "SAVE TRANSACTION " + name
+	case SavepointRollback :
+		return "ROLLBACK TRANSACTION " + name
+	default :
+		return ""
+	}
+}
+
+func (d sqlserverDialect) MaxPlaceholders() int {
+	return 2100
+}
+
+func (d sqlserverDialect) InsertIdStepQuery() string {
+	return ""
+}
+
+func (d sqlserverDialect) Upsert(table string, columns []string, values []string, conflictKey []string, updateColumns []string) string {
+	source := fmt.Sprintf("(VALUES (%s)) s (%s)", strings.Join(values, ", "), strings.Join(columns, ", "))
+	return mergeSql(table, source, columns, conflictKey, updateColumns) + ";"
+}
+
+// oracleDialect binds with name (:Name). same name appeared twice is bound once with sql.Named
+type oracleDialect struct {
+}
+
+func (d oracleDialect) Name() string {
+	return "oracle"
+}
+
+func (d oracleDialect) BindStyle() BindStyle {
+	return BindNamed
+}
+
+func (d oracleDialect) Placeholder(index int, name string) string {
+	if len(name) == 0 {
+		return fmt.Sprintf(":val%d", index)
+	}
+	return ":" + name
+}
+
+func (d oracleDialect) NamedArg(name string, value interface{}) interface{} {
+	return sql.Named(name, value)
+}
+
+func (d oracleDialect) QuoteIdentifier(name string) string {
+	return quoteIdentifier(name, `"`, `"`)
+}
+
+func (d oracleDialect) Paginate(query string, offset int, limit int) string {
+	return fmt.Sprintf("%s OFFSET %d ROWS FETCH NEXT %d ROWS ONLY", query, offset, limit)
+}
+
+func (d oracleDialect) BackslashEscape() bool {
+	return false
+}
+
+func (d oracleDialect) DollarQuote() bool {
+	return false
+}
+
+func (d oracleDialect) SupportLastInsertId() bool {
+	return false
+}
+
+func (d oracleDialect) ReturningClause() string {
+	return ""
+}
+
+func (d oracleDialect) MultiRowInsertId(lastInsertId int64, rows int64) int64 {
+	return lastInsertId
+}
+
+func (d oracleDialect) Savepoint(action SavepointAction, name string) string {
+	return savepointSql(action, name, false)
+}
+
+func (d oracleDialect) MaxPlaceholders() int {
+	return 65535
+}
+
+func (d oracleDialect) InsertIdStepQuery() string {
+	return ""
+}
+
+func (d oracleDialect) Upsert(table string, columns []string, values []string, conflictKey []string, updateColumns []string) string {
+	selects := make([]string, 0)
+	for i, v := range values {
+		selects = append(selects, fmt.Sprintf("%s %s", v, columns[i]))
+	}
+	source := fmt.Sprintf("(SELECT %s FROM dual) s", strings.Join(selects, ", "))
+	return mergeSql(table, source, columns, conflictKey, updateColumns)
+}
+
+func newDialectLexer(dialect Dialect, query string) *sqlLexer {
+	return newSqlLexer(query, dialect.BackslashEscape(), dialect.DollarQuote())
+}
@@ -0,0 +1,306 @@
+//
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+//
+// @project queryman
+// @author agent
+// @date 2026. 10. 18. PM 11:27
+//
+
+package queryman
+
+import (
+	"database/sql"
+	"testing"
+)
+
+func TestFindDialect(t *testing.T) {
+	for _, v := range []string{"postgres", "postgresql", "pgx", "PGX"} {
+		if findDialect(v).Name() != "postgres" {
+			t.Errorf("%s should be postgres dialect", v)
+		}
+	}
+	if findDialect("mysql").Name() != "mysql" || findDialect("").Name() != "mysql" {
+		t.Errorf("mysql should be default dialect")
+	}
+}
+
+func TestPostgresPlaceholder(t *testing.T) {
+	stmt := buildTestStatement(t, "postgres", QueryStatement{Id: "SelectCity", Query: "SELECT * FROM CITY WHERE NAME={Name} AND AGE={Age}"})
+	if stmt.Query != "SELECT * FROM CITY WHERE NAME=$1 AND AGE=$2" {
+		t.Fatalf("invalid query : %s", stmt.Query)
+	}
+
+	stmt = buildTestStatement(t, "postgres", QueryStatement{Id: "SelectCityIn", Query: "SELECT * FROM CITY WHERE NAME IN ({Names}) AND AGE > {Age} AND ID IN ({Ids})"})
+	m := map[string]interface{}{"Names": []string{"a", "b"}, "Age": 10, "Ids": []interface{}{1, 2, 3}}
+	query, param, bindErr := resolveColumnBindInMap(stmt, m)
+	if bindErr != nil {
+		t.Fatalf("fail to bind : %s", bindErr.err.Error())
+	}
+	if query != "SELECT * FROM CITY WHERE NAME IN ($1,$2) AND AGE > $3 AND ID IN ($4,$5,$6)" || len(param) != 6 {
+		t.Fatalf("invalid expansion : %s, %v", query, param)
+	}
+
+	query, param, err := resolveColumnBindInList(stmt, []interface{}{[]string{"a"}, 10, []int{1, 2}})
+	if err != nil {
+		t.Fatalf("fail to bind : %s", err.Error())
+	}
+	if query != "SELECT * FROM CITY WHERE NAME IN ($1) AND AGE > $2 AND ID IN ($3,$4)" || len(param) != 4 {
+		t.Fatalf("invalid expansion : %s, %v", query, param)
+	}
+}
+
+func TestPostgresReturning(t *testing.T) {
+	stmt := buildTestStatement(t, "postgres", QueryStatement{Id: "InsertCity", Query: "INSERT INTO CITY(NAME,AGE) VALUES({Name},{Age})", KeyColumn: "id"})
+	if !stmt.returning || stmt.Query != "INSERT INTO CITY(NAME,AGE) VALUES($1,$2) RETURNING id" {
+		t.Fatalf("invalid returning query : %s", stmt.Query)
+	}
+	if stmt.collectInsertId() {
+		t.Fatalf("postgres should not collect LastInsertId")
+	}
+
+	stmt = buildTestStatement(t, "postgres", QueryStatement{Id: "InsertCityReturning", Query: "INSERT INTO CITY(NAME) VALUES({Name}) returning id, name"})
+	if !stmt.returning || stmt.Query != "INSERT INTO CITY(NAME) VALUES($1) returning id, name" {
+		t.Fatalf("invalid returning query : %s", stmt.Query)
+	}
+
+	stmt = buildTestStatement(t, "postgres", QueryStatement{Id: "InsertCityLiteral", Query: "INSERT INTO CITY(NAME) VALUES('returning')", KeyColumn: "id"})
+	if !stmt.returning || stmt.Query != "INSERT INTO CITY(NAME) VALUES('returning') RETURNING id" {
+		t.Fatalf("keyColumn should be returned : %s", stmt.Query)
+	}
+
+	stmt = buildTestStatement(t, "mysql", QueryStatement{Id: "InsertCityMysql", Query: "INSERT INTO CITY(NAME) VALUES({Name})"})
+	if stmt.returning || !stmt.collectInsertId() {
+		t.Fatalf("mysql should collect LastInsertId")
+	}
+}
+
+func TestReturningClause(t *testing.T) {
+	stmt := buildTestStatement(t, "sqlserver", QueryStatement{Query: "INSERT INTO CITY(NAME) OUTPUT INSERTED.id VALUES({Name})", KeyColumn: "id"})
+	if !stmt.returning || stmt.Query != "INSERT INTO CITY(NAME) OUTPUT INSERTED.id VALUES(@p1)" {
+		t.Fatalf("rows of OUTPUT should be collected : %s", stmt.Query)
+	}
+
+	stmt = buildTestStatement(t, "sqlite3", QueryStatement{Query: "INSERT INTO CITY(NAME) VALUES({Name})", KeyColumn: "id"})
+	if stmt.returning || !stmt.collectInsertId() || stmt.Query != "INSERT INTO CITY(NAME) VALUES(?)" {
+		t.Fatalf("sqlite should collect LastInsertId : %s", stmt.Query)
+	}
+
+	stmt = buildTestStatement(t, "oracle", QueryStatement{Query: "INSERT INTO CITY(NAME) VALUES({Name}) RETURNING id INTO {Id}"})
+	if stmt.returning {
+		t.Fatalf("RETURNING INTO of oracle does not return rows")
+	}
+
+	for driverName, query := range map[string]string{
+		"sqlserver" : "INSERT INTO CITY(NAME) VALUES({Name})",
+		"oracle" : "INSERT INTO CITY(NAME) VALUES({Name})",
+	} {
+		stmt := QueryStatement{Id: "InsertCity", eleType: eleTypeInsert, Query: query, KeyColumn: "id"}
+		if _, err := newTestQueryman(driverName).buildStatement(stmt); err == nil {
+			t.Errorf("keyColumn without returning clause should be rejected for %s", driverName)
+		}
+	}
+}
+
+func TestPostgresBulkQuery(t *testing.T) {
+	stmt := buildTestStatement(t, "postgres", QueryStatement{Id: "InsertCity", Query: "INSERT INTO CITY(NAME,AGE) VALUES({Name},{Age})", KeyColumn: "id"})
+
+	bulkInsertQuery, err := parseBulkInsertQuery(postgresDialect{}, stmt.HoldedQuery)
+	if err != nil {
+		t.Fatalf("fail to parse bulk query : %s", err.Error())
+	}
+	query := stmt.resolveHolding(bulkInsertQuery.buildMultiValueQuery(3), nil)
+	if query != "INSERT INTO CITY(NAME,AGE) VALUES ($1,$2),($3,$4),($5,$6)  RETURNING id" {
+		t.Fatalf("invalid bulk query : %s", query)
+	}
+}
+
+func TestSqlServerPlaceholder(t *testing.T) {
+	stmt := buildTestStatement(t, "sqlserver", QueryStatement{Id: "SelectCityIn", Query: "SELECT * FROM CITY WHERE NAME IN ({Names}) AND AGE > {Age}"})
+	if stmt.Query != "SELECT * FROM CITY WHERE NAME IN (@p1) AND AGE > @p2" {
+		t.Fatalf("invalid query : %s", stmt.Query)
+	}
+
+	query, param, err := resolveColumnBindInList(stmt, []interface{}{[]string{"a", "b"}, 10})
+	if err != nil {
+		t.Fatalf("fail to bind : %s", err.Error())
+	}
+	if query != "SELECT * FROM CITY WHERE NAME IN (@p1,@p2) AND AGE > @p3" || len(param) != 3 || param[2] != 10 {
+		t.Fatalf("invalid expansion : %s, %v", query, param)
+	}
+}
+
+func TestOracleNamedBind(t *testing.T) {
+	stmt := buildTestStatement(t, "godror", QueryStatement{Id: "SelectCity", Query: "SELECT * FROM CITY WHERE NAME={Name} OR ALIAS={Name} AND AGE > {Age}"})
+	if stmt.Query != "SELECT * FROM CITY WHERE NAME=:Name OR ALIAS=:Name AND AGE > :Age" {
+		t.Fatalf("invalid query : %s", stmt.Query)
+	}
+
+	_, param, bindErr := resolveColumnBindInMap(stmt, map[string]interface{}{"Name": "seoul", "Age": 10})
+	if bindErr != nil {
+		t.Fatalf("fail to bind : %s", bindErr.err.Error())
+	}
+	if len(param) != 2 || param[0] != sql.Named("Name", "seoul") || param[1] != sql.Named("Age", 10) {
+		t.Fatalf("invalid named args : %v", param)
+	}
+
+	stmt = buildTestStatement(t, "oci8", QueryStatement{Id: "SelectCityIn", Query: "SELECT * FROM CITY WHERE ID IN ({Ids}) AND AGE > {Age}"})
+	query, param, err := resolveColumnBindInList(stmt, []interface{}{[]int{1, 2}, 10})
+	if err != nil {
+		t.Fatalf("fail to bind : %s", err.Error())
+	}
+	if query != "SELECT * FROM CITY WHERE ID IN (:Ids_1,:Ids_2) AND AGE > :Age" || len(param) != 3 || param[1] != sql.Named("Ids_2", 2) {
+		t.Fatalf("invalid expansion : %s, %v", query, param)
+	}
+
+	bulk := newQuerymanBulk(nil, buildTestStatement(t, "oracle", QueryStatement{Id: "InsertCity", Query: "INSERT INTO CITY(NAME) VALUES({Name})"}), NewBulkOptions())
+	bulk.AddBatch("seoul")
+	if _, err := bulk.Execute(); err == nil {
+		t.Fatalf("bulk insert should not be supported for named binding")
+	}
+}
+
+func TestDialectQuoteAndPaginate(t *testing.T) {
+	quotes := map[string]string{
+		"mysql":     "`db`.`ci``ty`",
+		"postgres":  `"db"."ci` + "`" + `ty"`,
+		"sqlserver": "[db].[ci`ty]",
+	}
+	for driver, expected := range quotes {
+		if quoted := findDialect(driver).QuoteIdentifier("db.ci`ty"); quoted != expected {
+			t.Errorf("[%s] invalid quoted identifier : %s", driver, quoted)
+		}
+	}
+	if quoted := findDialect("sqlserver").QuoteIdentifier("a]b"); quoted != "[a]]b]" {
+		t.Errorf("invalid quoted identifier : %s", quoted)
+	}
+
+	pages := map[string]string{
+		"mysql":     "SELECT * FROM CITY ORDER BY ID LIMIT 10 OFFSET 20",
+		"sqlite3":   "SELECT * FROM CITY ORDER BY ID LIMIT 10 OFFSET 20",
+		"sqlserver": "SELECT * FROM CITY ORDER BY ID OFFSET 20 ROWS FETCH NEXT 10 ROWS ONLY",
+		"oracle":    "SELECT * FROM CITY ORDER BY ID OFFSET 20 ROWS FETCH NEXT 10 ROWS ONLY",
+	}
+	for driver, expected := range pages {
+		if page := findDialect(driver).Paginate("SELECT * FROM CITY ORDER BY ID", 20, 10); page != expected {
+			t.Errorf("[%s] invalid pagination : %s", driver, page)
+		}
+	}
+	if page := findDialect("sqlserver").Paginate("SELECT * FROM CITY", 0, 10); page != "SELECT * FROM CITY ORDER BY (SELECT NULL) OFFSET 0 ROWS FETCH NEXT 10 ROWS ONLY" {
+		t.Errorf("invalid pagination : %s", page)
+	}
+	if page := findDialect("mysql").Paginate("SELECT * FROM CITY", 0, 10); page != "SELECT * FROM CITY LIMIT 10" {
+		t.Errorf("invalid pagination : %s", page)
+	}
+}
+
+const databaseIdXml = `<?xml version="1.0" encoding="UTF-8"?>
+<query>
+	<select id="SelectNow" databaseId="postgres">
+		SELECT NOW()::text
+	</select>
+	<select id="SelectNow">
+		SELECT NOW()
+	</select>
+	<select id="SelectNow" databaseId="sqlite3">
+		SELECT datetime('now')
+	</select>
+</query>`
+
+func TestDatabaseIdVariants(t *testing.T) {
+	expected := map[string]string{
+		"postgres" : "SELECT NOW()::text",
+		"pgx" : "SELECT NOW()::text",
+		"sqlite3" : "SELECT datetime('now')",
+		"mysql" : "SELECT NOW()",
+	}
+	for driverName, query := range expected {
+		man, err := registTestXml(driverName, databaseIdXml)
+		if err != nil {
+			t.Fatalf("%s : fail to regist : %s", driverName, err.Error())
+		}
+		stmt, err := man.find("SelectNow")
+		if err != nil {
+			t.Fatalf("%s : %s", driverName, err.Error())
+		}
+		if stmt.Query != query {
+			t.Errorf("%s : invalid variant : %s", driverName, stmt.Query)
+		}
+	}
+
+	_, err := registTestXml("mysql", `<query>
+	<select id="SelectNow" databaseId="postgres">SELECT 1</select>
+	<select id="SelectNow" databaseId="postgresql">SELECT 2</select>
+</query>`)
+	if err == nil {
+		t.Errorf("duplicated variant should be rejected")
+	}
+
+	_, err = registTestXml("mysql", `<query><select id="SelectNow" databaseId="db2">SELECT 1</select></query>`)
+	if err == nil {
+		t.Errorf("unknown databaseId should be rejected")
+	}
+}
+
+func TestDialectSavepoint(t *testing.T) {
+	if sql := findDialect("mysql").Savepoint(SavepointRollback, "sp1"); sql != "ROLLBACK TO SAVEPOINT sp1" {
+		t.Errorf("invalid mysql savepoint : %s", sql)
+	}
+	if sql := findDialect("sqlserver").Savepoint(SavepointCreate, "sp1"); sql != "SAVE TRANSACTION sp1" {
+		t.Errorf("invalid sqlserver savepoint : %s", sql)
+	}
+	if sql := findDialect("oracle").Savepoint(SavepointRelease, "sp1"); sql != "" {
+		t.Errorf("oracle has no release savepoint : %s", sql)
+	}
+	if isSavepointName("sp1; DROP TABLE city") {
+		t.Errorf("savepoint name should be identifier")
+	}
+}
+
+func TestUpsert(t *testing.T) {
+	expected := map[string]string{
+		"mysql": "INSERT INTO album (id, `score`) VALUES (?, ?) ON DUPLICATE KEY UPDATE `score` = VALUES(`score`)",
+		"postgres": "INSERT INTO album (id, `score`) VALUES ($1, $2) ON CONFLICT (id) DO UPDATE SET `score` = EXCLUDED.`score`",
+		"sqlserver": "MERGE INTO album t USING (VALUES (@p1, @p2)) s (id, `score`) ON (t.id = s.id) " +
+			"WHEN MATCHED THEN UPDATE SET t.`score` = s.`score` WHEN NOT MATCHED THEN INSERT (id, `score`) VALUES (s.id, s.`score`);",
+		"oracle": "MERGE INTO album t USING (SELECT :Id id, :Score `score` FROM dual) s ON (t.id = s.id) " +
+			"WHEN MATCHED THEN UPDATE SET t.`score` = s.`score` WHEN NOT MATCHED THEN INSERT (id, `score`) VALUES (s.id, s.`score`)",
+	}
+
+	for driverName, query := range expected {
+		stmt := QueryStatement{Id: "upsertAlbum", eleType: eleTypeInsert, upsert: true, ConflictKey: "ID"}
+		stmt.Query = "INSERT INTO album (id, `score`) VALUES ({Id}, {Score})"
+		built := buildTestStatement(t, driverName, stmt)
+		if built.Query != query {
+			t.Errorf("invalid %s upsert : %s", driverName, built.Query)
+		}
+	}
+
+	man := newTestQueryman("mysql")
+	for _, v := range []QueryStatement{
+		{Id: "noKey", Query: "INSERT INTO album (id) VALUES ({Id})"},
+		{Id: "unknownKey", ConflictKey: "name", Query: "INSERT INTO album (id) VALUES ({Id})"},
+		{Id: "suffix", ConflictKey: "id", Query: "INSERT INTO album (id) VALUES ({Id}) ON DUPLICATE KEY UPDATE id=id"},
+		{Id: "count", ConflictKey: "id", Query: "INSERT INTO album (id, score) VALUES ({Id})"},
+	} {
+		v.eleType = eleTypeInsert
+		v.upsert = true
+		if _, err := man.buildStatement(v); err == nil {
+			t.Errorf("upsert %s should be failed", v.Id)
+		}
+	}
+}
@@ -29,6 +29,7 @@ import (
 	"strings"
 	"fmt"
 	"bytes"
+	"strconv"
 	"time"
 	)
 
@@ -63,6 +64,7 @@ func buildElementType(stmt string) declareElementType	{
 	switch strings.ToLower(stmt)	{
 	case "select" :	return eleTypeSelect
 	case "insert" :	return eleTypeInsert
+	case "upsert" :	return eleTypeInsert
 	case "update" :	return eleTypeUpdate
 	case "delete" :	return eleTypeUpdate
 	case "if" :	return eleTypeIf
@@ -83,6 +85,14 @@ var (
 	ErrNilPtr                     = errors.New("destination pointer is nil")
 	ErrNoRows                     = errors.New("sql: no rows in result set")
 	ErrNoInsertId                 = errors.New("sql: no insert id")
+	ErrNoShardKey                 = errors.New("shard key is not found in parameter")
+	ErrReadOnlyTransaction        = errors.New("execution is not permitted in read only transaction")
+	ErrTransactionNotClosed       = errors.New("transaction is rolled back by finalizer")
+	ErrTransactionExists          = errors.New("transaction exists in context")
+	ErrNotInTransaction           = errors.New("executor is not in transaction")
+	ErrBulkWriterClosed           = errors.New("bulk writer is closed")
+	ErrBatchItemFailed            = errors.New("some items of batch failed")
+	ErrBatchDynamicStatement      = errors.New("dynamic statement is not supported in batch")
 )
 
 
@@ -98,9 +108,18 @@ type SqlProxy interface {
 type SqlDebugger interface {
 	debugEnabled() bool
 	debugPrint(string, ...interface{})
+	errorPrint(string, ...interface{})
 	recordExcution(stmtId string, start time.Time)
 }
 
+// StatementExecutor is implemented by both QueryMan and DBTransaction.
+// code generated by 'queryman gen' calls statements through this interface
+type StatementExecutor interface {
+	ExecuteWithStmt(stmtIdOrUserQuery string, v ...interface{}) (sql.Result, error)
+	QueryWithStmt(stmtIdOrUserQuery string, v ...interface{}) *QueryResult
+	QueryRowWithStmt(stmtIdOrUserQuery string, v ...interface{}) *QueryRowResult
+}
+
 type QueryStatementFinder interface {
 	find(id string)	(QueryStatement, error)
 }
@@ -109,9 +128,22 @@ type QueryStatement struct {
 	eleType       declareElementType
 	Id            string		`xml:"id,attr"`
 	Query         string		`xml:",cdata"`
-	clause        []IfClause	`xml:"if"`
+	ParamType     string		`xml:"paramType,attr"`
+	ResultType    string		`xml:"resultType,attr"`
+	KeyColumn     string		`xml:"keyColumn,attr"`
+	KeyProperty   string		`xml:"keyProperty,attr"`
+	DatabaseId    string		`xml:"databaseId,attr"`
+	UseMaster     bool		`xml:"useMaster,attr"`
+	ConflictKey   string		`xml:"conflictKey,attr"`
+	UpdateColumns string		`xml:"updateColumns,attr"`
+	upsert        bool
+	clause        []IfClause
 	columnMention []ColumnBind
 	HoldedQuery   string
+	paramDecl     *statementType
+	resultDecl    *statementType
+	normalizer    QueryNormalizer
+	returning     bool
 }
 
 func (q QueryStatement) hasArrayBind()	bool	{
@@ -161,6 +193,119 @@ type ColumnBind struct {
 	name 		string
 	holdPos		int
 	bindType 	columnBindType
+	kind		columnKind
+	optional	bool
+	hasDefault	bool
+	defaultValue	interface{}
+}
+
+/*
+placeholder modifiers
+
+	{Name}			required
+	{Note?}			optional. bind NULL when missing
+	{Status=ACTIVE}		bind 'ACTIVE' when missing
+	{Limit=100:int}		bind int64(100) when missing
+	{Age:int}		declare type only
+*/
+func parseColumnBind(placeholder string, pos int, bindType columnBindType) (ColumnBind, error) {
+	b := ColumnBind{}
+	b.holdPos = pos
+	b.bindType = bindType
+	b.kind = columnKindAny
+
+	spec := strings.Trim(placeholder, cutset)
+	defaultValue := ""
+	typeName := ""
+	if eq := strings.IndexByte(spec, '='); eq >= 0 {
+		defaultValue = spec[eq+1:]
+		spec = spec[:eq]
+		b.hasDefault = true
+		if colon := strings.LastIndexByte(defaultValue, ':'); colon >= 0 {
+			if _, err := buildColumnKind(strings.Trim(defaultValue[colon+1:], cutset)); err == nil {
+				typeName = strings.Trim(defaultValue[colon+1:], cutset)
+				defaultValue = defaultValue[:colon]
+			}
+		}
+	} else if colon := strings.IndexByte(spec, ':'); colon >= 0 {
+		typeName = strings.Trim(spec[colon+1:], cutset)
+		spec = spec[:colon]
+	}
+
+	spec = strings.Trim(spec, cutset)
+	if strings.HasSuffix(spec, "?") {
+		b.optional = true
+		spec = strings.TrimRight(spec[:len(spec)-1], cutset)
+	}
+
+	b.name = spec
+	if len(b.name) == 0 || strings.ContainsAny(b.name, " \t\r\n?=:'\"") {
+		return b, fmt.Errorf("invalid variable declare format : {%s}", placeholder)
+	}
+
+	if len(typeName) > 0 {
+		kind, err := buildColumnKind(typeName)
+		if err != nil {
+			return b, fmt.Errorf("invalid variable type {%s} : %s", placeholder, err.Error())
+		}
+		b.kind = kind
+	}
+
+	if b.hasDefault {
+		value, err := convertDefaultValue(strings.Trim(defaultValue, cutset), b.kind)
+		if err != nil {
+			return b, fmt.Errorf("invalid default value {%s} : %s", placeholder, err.Error())
+		}
+		b.defaultValue = value
+	}
+
+	return b, nil
+}
+
+func convertDefaultValue(value string, kind columnKind) (interface{}, error) {
+	if len(value) > 1 && value[0] == '\'' && value[len(value)-1] == '\'' {
+		value = value[1:len(value)-1]
+	}
+
+	switch kind {
+	case columnKindInt :
+		return strconv.ParseInt(value, 10, 64)
+	case columnKindUint :
+		return strconv.ParseUint(value, 10, 64)
+	case columnKindFloat :
+		return strconv.ParseFloat(value, 64)
+	case columnKindBool :
+		return strconv.ParseBool(value)
+	case columnKindTime :
+		return parseTimeString(value)
+	case columnKindBytes :
+		return []byte(value), nil
+	}
+	return value, nil
+}
+
+// required reports whether parameter value should be passed for this column
+func (c ColumnBind) required() bool {
+	return !c.optional && !c.hasDefault
+}
+
+// valueFrom returns binding value of column in m. default value or NULL is returned when missing
+func (c ColumnBind) valueFrom(m map[string]interface{}) (interface{}, bool) {
+	if found, ok := m[c.name]; ok {
+		return found, true
+	}
+	return c.missingValue()
+}
+
+// missingValue returns binding value when parameter is not passed
+func (c ColumnBind) missingValue() (interface{}, bool) {
+	if c.hasDefault {
+		return c.defaultValue, true
+	}
+	if c.optional {
+		return nil, true
+	}
+	return nil, false
 }
 
 func NewColumnBind(name string, pos int) ColumnBind {
@@ -187,11 +332,80 @@ func (c ColumnBind) String() string {
 	return fmt.Sprintf("%s/%d/%s", c.name, c.holdPos, c.bindType)
 }
 
+// requiredBindCount returns the number of columns which should be passed as parameter
+func (q QueryStatement) requiredBindCount() int {
+	count := 0
+	for _, v := range q.columnMention {
+		if v.required() {
+			count++
+		}
+	}
+	return count
+}
+
+// bindMap returns binding values of columns from map parameter
+func (q QueryStatement) bindMap(m map[string]interface{}) ([]interface{}, error) {
+	param := make([]interface{}, 0, len(q.columnMention))
+	for _, v := range q.columnMention {
+		found, ok := v.valueFrom(m)
+		if !ok {
+			return param, newStatementTypeError(q.Id, v.Name(), "not found from parameter values")
+		}
+		param = append(param, found)
+	}
+	return param, nil
+}
+
+// acceptArgCount reports whether n list parameters are enough to bind all columns
+func (q QueryStatement) acceptArgCount(n int) bool {
+	if n >= len(q.columnMention) {
+		return true
+	}
+	for _, v := range q.columnMention[n:] {
+		if v.required() {
+			return false
+		}
+	}
+	return true
+}
+
+// fillMissingArgs appends default values (or NULL) of trailing columns which are not passed in list parameter
+func (q QueryStatement) fillMissingArgs(args []interface{}) ([]interface{}, error) {
+	if len(q.columnMention) <= len(args) {
+		return args, nil
+	}
+
+	if !q.acceptArgCount(len(args)) {
+		return args, fmt.Errorf("binding parameter count mismatch. defined=%d, args=%d", len(q.columnMention), len(args))
+	}
+
+	filled := make([]interface{}, len(args), len(q.columnMention))
+	copy(filled, args)
+	for _, v := range q.columnMention[len(args):] {
+		value, _ := v.missingValue()
+		filled = append(filled, value)
+	}
+	return filled, nil
+}
+
 func (stmt QueryStatement) clone() QueryStatement {
 	clone := QueryStatement{}
 	clone.eleType = stmt.eleType
 	clone.Id = stmt.Id
 	clone.Query = stmt.Query
+	clone.ParamType = stmt.ParamType
+	clone.ResultType = stmt.ResultType
+	clone.paramDecl = stmt.paramDecl
+	clone.resultDecl = stmt.resultDecl
+	clone.KeyColumn = stmt.KeyColumn
+	clone.KeyProperty = stmt.KeyProperty
+	clone.DatabaseId = stmt.DatabaseId
+	clone.UseMaster = stmt.UseMaster
+	clone.ConflictKey = stmt.ConflictKey
+	clone.UpdateColumns = stmt.UpdateColumns
+	clone.upsert = stmt.upsert
+	clone.normalizer = stmt.normalizer
+	clone.returning = stmt.returning
 	clone.HoldedQuery = stmt.HoldedQuery
 	clone.clause = make([]IfClause, 0)
 	for _, v := range stmt.clause {
@@ -248,10 +462,92 @@ func (stmt QueryStatement) RefineStatement(params map[string]interface{}) (Query
 			}
 		}
 	}
-	err := queryNormalizer.normalize(&refined)
+	err := refined.getNormalizer().normalize(&refined)
 	return refined, err
 }
 
+// getNormalizer returns normalizer of QueryMan which builds the statement
+func (stmt QueryStatement) getNormalizer() QueryNormalizer {
+	if stmt.normalizer != nil {
+		return stmt.normalizer
+	}
+	if queryNormalizer != nil {
+		return queryNormalizer
+	}
+	return newNormalizer("")
+}
+
+func (stmt QueryStatement) resolveHolding(holdedQuery string, names []string) string {
+	return stmt.getNormalizer().resolveHolding(holdedQuery, names)
+}
+
+// holdNames returns bind names of holds in HoldedQuery
+func (stmt QueryStatement) holdNames() []string {
+	names := make([]string, len(stmt.columnMention))
+	for i, v := range stmt.columnMention {
+		names[i] = v.Name()
+	}
+	return names
+}
+
+// bindArgs converts arguments of holds for named binding dialect.
+// argument of same name is passed once
+func (stmt QueryStatement) bindArgs(names []string, param []interface{}) []interface{} {
+	dialect := stmt.getNormalizer().getDialect()
+	if dialect.BindStyle() != BindNamed || len(names) != len(param) {
+		return param
+	}
+
+	args := make([]interface{}, 0, len(param))
+	bound := make(map[string]bool)
+	for i, v := range param {
+		if bound[names[i]] {
+			continue
+		}
+		bound[names[i]] = true
+		args = append(args, dialect.NamedArg(names[i], v))
+	}
+	return args
+}
+
+// collectInsertId checks generated key should be collected with LastInsertId
+func (stmt QueryStatement) collectInsertId() bool {
+	return stmt.eleType == eleTypeInsert && !stmt.returning && stmt.getNormalizer().getDialect().SupportLastInsertId()
+}
+
+// prepareReturning marks insert statement whose generated keys are returned as rows (RETURNING, OUTPUT).
+// RETURNING clause of keyColumn is appended when the database does not support LastInsertId
+func prepareReturning(stmt *QueryStatement) error {
+	if stmt.eleType != eleTypeInsert {
+		return nil
+	}
+
+	dialect := stmt.getNormalizer().getDialect()
+	clause := dialect.ReturningClause()
+	if len(clause) > 0 && newDialectLexer(dialect, stmt.Query).containsKeyword(clause) {
+		stmt.returning = true
+		return nil
+	}
+
+	if dialect.SupportLastInsertId() || len(stmt.KeyColumn) == 0 {
+		return nil
+	}
+	if len(clause) == 0 {
+		return fmt.Errorf("keyColumn of stmt [%s] is not supported by %s", stmt.Id, dialect.Name())
+	}
+	if clause != keywordReturning {
+		return fmt.Errorf("keyColumn of stmt [%s] needs %s clause in the query for %s", stmt.Id, clause, dialect.Name())
+	}
+
+	returning := fmt.Sprintf(" %s %s", keywordReturning, stmt.KeyColumn)
+	stmt.Query = stmt.Query + returning
+	if len(stmt.HoldedQuery) > 0 {
+		stmt.HoldedQuery = stmt.HoldedQuery + returning
+	}
+	stmt.returning = true
+	return nil
+}
+
 func (stmt *QueryStatement) appendIf(clause IfClause)  {
 	stmt.clause = append(stmt.clause, clause)
 }
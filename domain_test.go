@@ -0,0 +1,217 @@
+//
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+//
+// @project queryman
+// @author agent
+// @date 2026. 10. 18. PM 11:22
+//
+
+package queryman
+
+import (
+	"testing"
+)
+
+func TestParseColumnBind(t *testing.T) {
+	b, err := parseColumnBind("Status=ACTIVE", 1, columnBindTypeNormal)
+	if err != nil || b.Name() != "Status" || !b.hasDefault || b.defaultValue != "ACTIVE" {
+		t.Fatalf("invalid default bind : %v, %v", b, err)
+	}
+
+	b, err = parseColumnBind("Limit=100:int", 1, columnBindTypeNormal)
+	if err != nil || b.Name() != "Limit" || b.kind != columnKindInt || b.defaultValue != int64(100) {
+		t.Fatalf("invalid typed default bind : %v, %v", b, err)
+	}
+
+	b, err = parseColumnBind("Note?", 1, columnBindTypeNormal)
+	if err != nil || b.Name() != "Note" || !b.optional || b.required() {
+		t.Fatalf("invalid optional bind : %v, %v", b, err)
+	}
+
+	b, err = parseColumnBind("Start=10:00", 1, columnBindTypeNormal)
+	if err != nil || b.defaultValue != "10:00" {
+		t.Fatalf("colon in default value should be kept : %v, %v", b, err)
+	}
+
+	b, err = parseColumnBind("Name='hello world'", 1, columnBindTypeNormal)
+	if err != nil || b.defaultValue != "hello world" {
+		t.Fatalf("quoted default value : %v, %v", b, err)
+	}
+
+	invalid := []string{"", "?", "Limit=abc:int", "Age:unknown", "Na me"}
+	for _, v := range invalid {
+		if _, err := parseColumnBind(v, 1, columnBindTypeNormal); err == nil {
+			t.Errorf("expect error for {%s}", v)
+		}
+	}
+}
+
+func TestBindDefaultValues(t *testing.T) {
+	stmt := buildTestStatement(t, "mysql", QueryStatement{Query: "SELECT * FROM CITY WHERE NAME={Name} AND STATUS={Status=ACTIVE} AND NOTE={Note?} LIMIT {Limit=100:int}"})
+	if stmt.Query != "SELECT * FROM CITY WHERE NAME=? AND STATUS=? AND NOTE=? LIMIT ?" {
+		t.Fatalf("invalid normalized query : %s", stmt.Query)
+	}
+
+	param, err := stmt.bindMap(map[string]interface{}{"Name": "seoul"})
+	if err != nil {
+		t.Fatalf("fail to bind : %s", err.Error())
+	}
+	if len(param) != 4 || param[1] != "ACTIVE" || param[2] != nil || param[3] != int64(100) {
+		t.Fatalf("invalid binding : %v", param)
+	}
+
+	param, err = stmt.bindMap(map[string]interface{}{"Name": "seoul", "Limit": 5, "Note": "memo"})
+	if err != nil || param[2] != "memo" || param[3] != 5 {
+		t.Fatalf("passed value should be used : %v", param)
+	}
+
+	_, err = stmt.bindMap(map[string]interface{}{"Status": "CLOSED"})
+	if typeErr, ok := err.(*StatementTypeError); !ok || typeErr.Field != "Name" {
+		t.Fatalf("expect missing Name error but %v", err)
+	}
+
+	args, err := stmt.fillMissingArgs([]interface{}{"seoul"})
+	if err != nil || len(args) != 4 || args[1] != "ACTIVE" || args[3] != int64(100) {
+		t.Fatalf("invalid list binding : %v, %v", args, err)
+	}
+
+	_, err = stmt.fillMissingArgs([]interface{}{})
+	if err == nil {
+		t.Fatalf("expect count mismatch error")
+	}
+}
+
+func TestBindDefaultArray(t *testing.T) {
+	queryNormalizer = newNormalizer("mysql")
+	stmt := buildTestStatement(t, "mysql", QueryStatement{Query: "SELECT * FROM CITY WHERE ID IN ({Ids}) AND STATUS={Status=ACTIVE}"})
+
+	query, param, bindErr := resolveColumnBindInMap(stmt, map[string]interface{}{"Ids": []int{1, 2, 3}})
+	if bindErr != nil {
+		t.Fatalf("fail to bind : %s", bindErr.err.Error())
+	}
+	if query != "SELECT * FROM CITY WHERE ID IN (?,?,?) AND STATUS=?" || len(param) != 4 || param[3] != "ACTIVE" {
+		t.Fatalf("invalid binding : %s, %v", query, param)
+	}
+
+	_, _, bindErr = resolveColumnBindInMap(stmt, map[string]interface{}{"Status": "CLOSED"})
+	if bindErr == nil {
+		t.Fatalf("expect missing Ids error")
+	}
+}
+
+func TestBulkDefaultValues(t *testing.T) {
+	stmt := buildTestStatement(t, "mysql", QueryStatement{Query: "INSERT INTO CITY(NAME,STATUS,NOTE) VALUES({Name},{Status=ACTIVE},{Note?})"})
+
+	type Town struct {
+		Name string
+	}
+
+	b := newQuerymanBulk(nil, stmt, NewBulkOptions())
+	if err := b.AddBatch(map[string]interface{}{"Name": "seoul"}); err != nil {
+		t.Fatalf("fail to add map : %s", err.Error())
+	}
+	if err := b.AddBatch(Town{Name: "busan"}); err != nil {
+		t.Fatalf("fail to add struct : %s", err.Error())
+	}
+	if err := b.AddBatch([]interface{}{"daegu"}); err != nil {
+		t.Fatalf("fail to add list : %s", err.Error())
+	}
+	if err := b.AddBatch([][]interface{}{{"incheon", "CLOSED"}}); err != nil {
+		t.Fatalf("fail to add nested list : %s", err.Error())
+	}
+
+	if b.execCount != 4 || len(b.params) != 12 {
+		t.Fatalf("invalid bulk params : %s", b)
+	}
+	if b.params[1] != "ACTIVE" || b.params[5] != nil || b.params[7] != "ACTIVE" || b.params[10] != "CLOSED" {
+		t.Fatalf("invalid bulk params : %v", b.params)
+	}
+
+	if err := b.AddBatch(map[string]interface{}{"Status": "CLOSED"}); err == nil {
+		t.Fatalf("expect missing Name error")
+	}
+}
+
+func TestBulkNativeInsert(t *testing.T) {
+	stmt := buildTestStatement(t, "mysql", QueryStatement{Query: "INSERT INTO CITY(NAME,AGE,NOTE) VALUES({Name},{Age},{Note})"})
+	b := newQuerymanBulk(nil, stmt, NewBulkOptions())
+	insert, ok := b.nativeInsert(mysqlDialect{})
+	if !ok || insert.table != "CITY" || len(insert.columns) != 3 {
+		t.Fatalf("insert should be loadable : %v", insert)
+	}
+
+	for _, query := range []string{
+		"INSERT INTO CITY(NAME,AGE) VALUES({Name},NOW())",
+		"INSERT INTO CITY(NAME,AGE) VALUES({Name},{Age}) ON DUPLICATE KEY UPDATE AGE=VALUES(AGE)",
+	} {
+		b = newQuerymanBulk(nil, buildTestStatement(t, "mysql", QueryStatement{Query: query}), NewBulkOptions())
+		if _, ok = b.nativeInsert(mysqlDialect{}); ok {
+			t.Errorf("insert should not be loadable : %s", query)
+		}
+	}
+}
+
+func TestBulkCopyInsert(t *testing.T) {
+	stmt := buildTestStatement(t, "postgres", QueryStatement{Query: "INSERT INTO city(name,age) VALUES({Name},{Age})"})
+	b := newQuerymanBulk(nil, stmt, NewBulkOptions())
+	insert, ok := b.nativeInsert(postgresDialect{})
+	if !ok || insert.table != "city" || insert.columns[1] != "age" {
+		t.Fatalf("insert should be copied : %v", insert)
+	}
+	RegisterNativeLoader("postgres", &liteLoader{})
+	defer RegisterNativeLoader("postgres", nil)
+	if _, ok = findNativeLoader(postgresDialect{}, contextProxy{}); ok {
+		t.Fatalf("proxy without database should not support copy")
+	}
+}
+
+func TestBulkReset(t *testing.T) {
+	stmt := buildTestStatement(t, "mysql", QueryStatement{Query: "INSERT INTO CITY(NAME,AGE) VALUES({Name},{Age})"})
+
+	type Town struct {
+		Name string
+		Age  int
+	}
+
+	b := newQuerymanBulk(nil, stmt, NewBulkOptions())
+	if err := b.AddBatch([]Town{{"seoul", 10}, {"busan", 20}}); err != nil {
+		t.Fatalf("fail to add struct list : %s", err.Error())
+	}
+	if b.Len() != 2 || b.EstimatedSize() != 2*(7+20) {
+		t.Fatalf("invalid bulk size : len=%d, size=%d", b.Len(), b.EstimatedSize())
+	}
+
+	// failed rows are discarded together
+	if err := b.AddBatch([]interface{}{Town{"daegu", 30}, map[string]interface{}{"Name": "ulsan", "Age": 40}}); err == nil {
+		t.Fatalf("mixed shape should be failed")
+	}
+	if err := b.AddBatch([][]interface{}{{"incheon", 50}, {"jeju", 60, "extra"}}); err == nil {
+		t.Fatalf("row having extra parameter should be failed")
+	}
+	if b.Len() != 2 || len(b.params) != 4 {
+		t.Fatalf("failed rows should not be added : %s", b)
+	}
+
+	b.Reset()
+	if b.Len() != 0 || b.EstimatedSize() != 0 || len(b.params) != 0 {
+		t.Fatalf("bulk should be empty after reset : %s", b)
+	}
+	if err := b.AddBatch([]interface{}{"gwangju", 70}); err != nil || b.Len() != 1 {
+		t.Fatalf("bulk should be reused after reset : %v", err)
+	}
+}
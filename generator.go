@@ -0,0 +1,491 @@
+//
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+//
+// @project queryman
+// @author agent
+// @date 2026. 10. 18. PM 11:10
+//
+
+package queryman
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"go/token"
+	"io"
+	"io/ioutil"
+	"strings"
+	"unicode"
+)
+
+const querymanImportPath = "throosea.com/queryman"
+
+// CodeGenerator emits go source which declares a constant per statement id
+// and a typed wrapper function per statement.
+// wrapper functions take a StatementExecutor, so they work with QueryMan and DBTransaction both
+type CodeGenerator struct {
+	Package    string
+	DriverName string
+	Imports    []string
+	statements []QueryStatement
+}
+
+func NewCodeGenerator(pkg string) *CodeGenerator {
+	g := &CodeGenerator{}
+	g.Package = pkg
+	g.DriverName = "mysql"
+	g.Imports = make([]string, 0)
+	g.statements = make([]QueryStatement, 0)
+	return g
+}
+
+func (g *CodeGenerator) AddFile(file string) error {
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return fmt.Errorf("fail to read file[%s] : %s", file, err.Error())
+	}
+
+	err = g.AddXml(data)
+	if err != nil {
+		return fmt.Errorf("fail to parse file[%s] : %s", file, err.Error())
+	}
+	return nil
+}
+
+func (g *CodeGenerator) AddXml(data []byte) error {
+	list, err := parseStatements(data)
+	if err != nil {
+		return err
+	}
+
+	for _, v := range list {
+		name, err := databaseIdName(v.DatabaseId)
+		if err != nil {
+			return fmt.Errorf("stmt [%s] : %s", v.Id, err.Error())
+		}
+		for _, exist := range g.statements {
+			existName, _ := databaseIdName(exist.DatabaseId)
+			if strings.ToUpper(exist.Id) == strings.ToUpper(v.Id) && existName == name {
+				return fmt.Errorf("duplicated user statement id : %s", v.Id)
+			}
+		}
+		g.statements = append(g.statements, v)
+	}
+	return nil
+}
+
+func (g *CodeGenerator) Generate(w io.Writer) error {
+	if len(g.Package) == 0 {
+		return fmt.Errorf("package name is empty")
+	}
+
+	normalizer := newNormalizer(g.DriverName)
+	if normalizer == nil {
+		return fmt.Errorf("not found normalizer for %s", g.DriverName)
+	}
+
+	statements, err := selectVariants(g.statements, normalizer.getDialect())
+	if err != nil {
+		return err
+	}
+
+	err = g.checkNames(normalizer, statements)
+	if err != nil {
+		return err
+	}
+
+	var body bytes.Buffer
+	body.WriteString("const (\n")
+	for _, v := range statements {
+		body.WriteString(fmt.Sprintf("\t%s = %q\n", g.constName(v), v.Id))
+	}
+	body.WriteString(")\n")
+
+	useSql := false
+	useTime := false
+	for _, v := range statements {
+		normalized, err := g.normalize(normalizer, v)
+		if err != nil {
+			return fmt.Errorf("stmt [%s] : %s", v.Id, err.Error())
+		}
+		placeholders := g.collectPlaceholders(normalized)
+
+		v.paramDecl, err = parseStatementType(v.ParamType)
+		if err != nil {
+			return fmt.Errorf("invalid paramType of stmt [%s] : %s", v.Id, err.Error())
+		}
+		if v.paramDecl == nil {
+			v.paramDecl = placeholderType(normalized)
+		}
+		v.resultDecl, err = parseStatementType(v.ResultType)
+		if err != nil {
+			return fmt.Errorf("invalid resultType of stmt [%s] : %s", v.Id, err.Error())
+		}
+		useTime = useTime || declareTime(v.paramDecl) || declareTime(v.resultDecl)
+
+		if v.eleType == eleTypeSelect {
+			g.writeQueryFunc(&body, v, placeholders)
+		} else {
+			g.writeExecuteFunc(&body, v, placeholders)
+			useSql = true
+		}
+	}
+
+	var buffer bytes.Buffer
+	buffer.WriteString("// Code generated by queryman gen. DO NOT EDIT.\n\n")
+	buffer.WriteString(fmt.Sprintf("package %s\n\n", g.Package))
+	buffer.WriteString("import (\n")
+	if useSql {
+		buffer.WriteString("\t\"database/sql\"\n")
+	}
+	if useTime {
+		buffer.WriteString("\t\"time\"\n")
+	}
+	buffer.WriteString(fmt.Sprintf("\t%q\n", querymanImportPath))
+	for _, v := range g.Imports {
+		buffer.WriteString(fmt.Sprintf("\t%q\n", v))
+	}
+	buffer.WriteString(")\n\n")
+	buffer.Write(body.Bytes())
+
+	source, err := format.Source(buffer.Bytes())
+	if err != nil {
+		return fmt.Errorf("fail to format generated source : %s", err.Error())
+	}
+
+	_, err = w.Write(source)
+	return err
+}
+
+// checkNames reports statement whose id has no exported go name,
+// generated identifiers (const, func and result type) declared twice and invalid parameter names
+func (g *CodeGenerator) checkNames(normalizer QueryNormalizer, statements []QueryStatement) error {
+	declared := make(map[string]string)
+	declare := func(name string, stmt QueryStatement) error {
+		if exist, ok := declared[name]; ok {
+			return fmt.Errorf("generated name %s of stmt [%s] collides with stmt [%s]", name, stmt.Id, exist)
+		}
+		declared[name] = stmt.Id
+		return nil
+	}
+
+	for _, v := range statements {
+		name := g.funcName(v)
+		if !token.IsIdentifier(name) || !token.IsExported(name) {
+			return fmt.Errorf("stmt [%s] has no valid exported go name : %q", v.Id, name)
+		}
+
+		names := []string{name, g.constName(v)}
+		if v.eleType == eleTypeSelect {
+			resultDecl, err := parseStatementType(v.ResultType)
+			if err != nil {
+				return fmt.Errorf("invalid resultType of stmt [%s] : %s", v.Id, err.Error())
+			}
+			if resultDecl != nil && resultDecl.isInline() {
+				names = append(names, name+"Result")
+			}
+		}
+		for _, n := range names {
+			if err := declare(n, v); err != nil {
+				return err
+			}
+		}
+
+		normalized, err := g.normalize(normalizer, v)
+		if err != nil {
+			return fmt.Errorf("stmt [%s] : %s", v.Id, err.Error())
+		}
+		params := make(map[string]string)
+		for placeholder, param := range g.paramNames(g.collectPlaceholders(normalized)) {
+			if !token.IsIdentifier(param) {
+				return fmt.Errorf("placeholder {%s} of stmt [%s] has no valid go name : %q", placeholder, v.Id, param)
+			}
+			if exist, ok := params[param]; ok {
+				return fmt.Errorf("parameter %s of stmt [%s] is declared for both {%s} and {%s}", param, v.Id, exist, placeholder)
+			}
+			params[param] = placeholder
+		}
+	}
+	return nil
+}
+
+func (g *CodeGenerator) constName(stmt QueryStatement) string {
+	return "Stmt" + g.funcName(stmt)
+}
+
+func (g *CodeGenerator) funcName(stmt QueryStatement) string {
+	return toGoIdentifier(CamelConvertStrategy{}.convertFieldName(stmt.Id))
+}
+
+func (g *CodeGenerator) normalize(normalizer QueryNormalizer, stmt QueryStatement) (QueryStatement, error) {
+	clone := stmt.clone()
+	if clone.HasCondition() {
+		return clone, nil
+	}
+	err := normalizer.normalize(&clone)
+	return clone, err
+}
+
+// collectPlaceholders returns the distinct placeholders of statement in declared order.
+// dynamic statements (having if clause) return nil because they are called with map
+func (g *CodeGenerator) collectPlaceholders(stmt QueryStatement) []ColumnBind {
+	if stmt.HasCondition() {
+		return nil
+	}
+
+	names := make([]string, 0)
+	placeholders := make([]ColumnBind, 0)
+	for _, v := range stmt.columnMention {
+		if !containsString(names, v.Name()) {
+			names = append(names, v.Name())
+			placeholders = append(placeholders, v)
+		}
+	}
+	return placeholders
+}
+
+// hasOmittable reports whether some placeholders are optional or have default value.
+// those are passed only when not nil, so default value works
+func (g *CodeGenerator) hasOmittable(placeholders []ColumnBind) bool {
+	for _, v := range placeholders {
+		if !v.required() {
+			return true
+		}
+	}
+	return false
+}
+
+func (g *CodeGenerator) resultTypeName(stmt QueryStatement) string {
+	if stmt.resultDecl.isInline() {
+		return g.funcName(stmt) + "Result"
+	}
+	return stmt.ResultType
+}
+
+func (g *CodeGenerator) writeParamDeclare(buffer *bytes.Buffer, stmt QueryStatement, placeholders []ColumnBind) {
+	if stmt.paramDecl != nil && !stmt.paramDecl.isInline() {
+		buffer.WriteString(fmt.Sprintf(", param %s", stmt.ParamType))
+		return
+	}
+
+	if stmt.HasCondition() {
+		buffer.WriteString(", params map[string]interface{}")
+		return
+	}
+
+	if len(placeholders) == 0 {
+		if strings.Contains(stmt.Query, "?") {
+			buffer.WriteString(", args ...interface{}")
+		}
+		return
+	}
+
+	names := g.paramNames(placeholders)
+	for _, v := range placeholders {
+		goType := "interface{}"
+		if stmt.paramDecl != nil && v.required() {
+			if c, ok := stmt.paramDecl.findColumn(v.Name()); ok {
+				goType = c.kind.goType()
+			}
+		}
+		buffer.WriteString(fmt.Sprintf(", %s %s", names[v.Name()], goType))
+	}
+}
+
+// writeParamPrepare declares map parameter when some placeholders can be omitted
+func (g *CodeGenerator) writeParamPrepare(buffer *bytes.Buffer, stmt QueryStatement, placeholders []ColumnBind) {
+	if stmt.HasCondition() || (stmt.paramDecl != nil && !stmt.paramDecl.isInline()) || !g.hasOmittable(placeholders) {
+		return
+	}
+
+	names := g.paramNames(placeholders)
+	buffer.WriteString("\tparams := map[string]interface{}{")
+	first := true
+	for _, v := range placeholders {
+		if !v.required() {
+			continue
+		}
+		if !first {
+			buffer.WriteString(", ")
+		}
+		first = false
+		buffer.WriteString(fmt.Sprintf("%q: %s", v.Name(), names[v.Name()]))
+	}
+	buffer.WriteString("}\n")
+
+	for _, v := range placeholders {
+		if v.required() {
+			continue
+		}
+		buffer.WriteString(fmt.Sprintf("\tif %s != nil {\n", names[v.Name()]))
+		buffer.WriteString(fmt.Sprintf("\t\tparams[%q] = %s\n", v.Name(), names[v.Name()]))
+		buffer.WriteString("\t}\n")
+	}
+}
+
+func (g *CodeGenerator) writeParamPassing(buffer *bytes.Buffer, stmt QueryStatement, placeholders []ColumnBind) {
+	buffer.WriteString(g.constName(stmt))
+	if stmt.paramDecl != nil && !stmt.paramDecl.isInline() {
+		buffer.WriteString(", param")
+		return
+	}
+
+	if stmt.HasCondition() {
+		buffer.WriteString(", params")
+		return
+	}
+
+	if len(placeholders) == 0 {
+		if strings.Contains(stmt.Query, "?") {
+			buffer.WriteString(", args...")
+		}
+		return
+	}
+
+	if g.hasOmittable(placeholders) {
+		buffer.WriteString(", params")
+		return
+	}
+
+	names := g.paramNames(placeholders)
+	buffer.WriteString(", map[string]interface{}{")
+	for i, v := range placeholders {
+		if i > 0 {
+			buffer.WriteString(", ")
+		}
+		buffer.WriteString(fmt.Sprintf("%q: %s", v.Name(), names[v.Name()]))
+	}
+	buffer.WriteString("}")
+}
+
+func (g *CodeGenerator) writeExecuteFunc(buffer *bytes.Buffer, stmt QueryStatement, placeholders []ColumnBind) {
+	buffer.WriteString(fmt.Sprintf("\n// %s executes statement '%s'\n", g.funcName(stmt), stmt.Id))
+	buffer.WriteString(fmt.Sprintf("func %s(ex queryman.StatementExecutor", g.funcName(stmt)))
+	g.writeParamDeclare(buffer, stmt, placeholders)
+	buffer.WriteString(") (sql.Result, error) {\n")
+	g.writeParamPrepare(buffer, stmt, placeholders)
+	buffer.WriteString("\treturn ex.ExecuteWithStmt(")
+	g.writeParamPassing(buffer, stmt, placeholders)
+	buffer.WriteString(")\n}\n")
+}
+
+func (g *CodeGenerator) writeQueryFunc(buffer *bytes.Buffer, stmt QueryStatement, placeholders []ColumnBind) {
+	if stmt.resultDecl != nil && stmt.resultDecl.isInline() {
+		buffer.WriteString(fmt.Sprintf("\n// %s is result row of statement '%s'\n", g.resultTypeName(stmt), stmt.Id))
+		buffer.WriteString(fmt.Sprintf("type %s struct {\n", g.resultTypeName(stmt)))
+		for _, c := range stmt.resultDecl.columns {
+			buffer.WriteString(fmt.Sprintf("\t%s %s\n", toGoIdentifier(CamelConvertStrategy{}.convertFieldName(c.name)), c.kind.goType()))
+		}
+		buffer.WriteString("}\n")
+	}
+
+	buffer.WriteString(fmt.Sprintf("\n// %s queries statement '%s'\n", g.funcName(stmt), stmt.Id))
+	buffer.WriteString(fmt.Sprintf("func %s(ex queryman.StatementExecutor", g.funcName(stmt)))
+	g.writeParamDeclare(buffer, stmt, placeholders)
+
+	if stmt.resultDecl == nil {
+		buffer.WriteString(") *queryman.QueryResult {\n")
+		g.writeParamPrepare(buffer, stmt, placeholders)
+		buffer.WriteString("\treturn ex.QueryWithStmt(")
+		g.writeParamPassing(buffer, stmt, placeholders)
+		buffer.WriteString(")\n}\n")
+		return
+	}
+
+	resultType := g.resultTypeName(stmt)
+	buffer.WriteString(fmt.Sprintf(") ([]%s, error) {\n", resultType))
+	g.writeParamPrepare(buffer, stmt, placeholders)
+	buffer.WriteString("\tresult := ex.QueryWithStmt(")
+	g.writeParamPassing(buffer, stmt, placeholders)
+	buffer.WriteString(")\n")
+	buffer.WriteString("\tif result.GetError() != nil {\n\t\treturn nil, result.GetError()\n\t}\n")
+	buffer.WriteString("\tdefer result.Close()\n\n")
+	buffer.WriteString(fmt.Sprintf("\tlist := make([]%s, 0)\n", resultType))
+	buffer.WriteString("\tfor result.Next() {\n")
+	buffer.WriteString(fmt.Sprintf("\t\tvar item %s\n", resultType))
+	buffer.WriteString("\t\tif err := result.Scan(&item); err != nil {\n\t\t\treturn list, err\n\t\t}\n")
+	buffer.WriteString("\t\tlist = append(list, item)\n")
+	buffer.WriteString("\t}\n")
+	buffer.WriteString("\treturn list, nil\n}\n")
+}
+
+func toGoIdentifier(name string) string {
+	var buffer bytes.Buffer
+	for i, c := range name {
+		if unicode.IsLetter(c) || c == '_' || (i > 0 && unicode.IsDigit(c)) {
+			buffer.WriteRune(c)
+		}
+	}
+	return buffer.String()
+}
+
+// paramNames maps placeholder to go parameter name. placeholders which differ only in case
+// (e.g. {Name} and {name}) get numbered suffix so that parameters are not declared twice
+func (g *CodeGenerator) paramNames(placeholders []ColumnBind) map[string]string {
+	names := make(map[string]string)
+	used := make(map[string]bool)
+	for _, v := range placeholders {
+		param := toGoParamName(v.Name())
+		if param == "_" {
+			param = "arg"
+		}
+		base := param
+		for seq := 2; used[param]; seq++ {
+			param = fmt.Sprintf("%s%d", base, seq)
+		}
+		used[param] = true
+		names[v.Name()] = param
+	}
+	return names
+}
+
+func toGoParamName(name string) string {
+	ident := []rune(toGoIdentifier(name))
+	if len(ident) == 0 {
+		return "_"
+	}
+	ident[0] = unicode.ToLower(ident[0])
+	param := string(ident)
+	if token.Lookup(param).IsKeyword() || param == "ex" || param == "result" || param == "list" ||
+		param == "param" || param == "params" || param == "args" {
+		param = param + "_"
+	}
+	return param
+}
+
+func declareTime(decl *statementType) bool {
+	if decl == nil {
+		return false
+	}
+	for _, c := range decl.columns {
+		if c.kind == columnKindTime {
+			return true
+		}
+	}
+	return false
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
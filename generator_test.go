@@ -0,0 +1,206 @@
+//
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+//
+// @project queryman
+// @author agent
+// @date 2026. 10. 18. PM 11:10
+//
+
+package queryman
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+var generatorData = []byte(`
+<query>
+    <update id="DropCityTable">
+        drop table if exists city
+    </update>
+    <insert id="InsertCity" paramType="*City">
+        INSERT INTO CITY(NAME,AGE) VALUES({Name},{Age})
+    </insert>
+    <update id="UpdateCity">
+        UPDATE CITY SET AGE=? WHERE IS_MAN=?
+    </update>
+    <update id="update_city_with_name">
+        UPDATE CITY SET AGE={Age} WHERE NAME={Name} OR NICK={Name} OR TYPE={type}
+    </update>
+    <select id="SelectCityWithName" resultType="City">
+        SELECT * FROM CITY WHERE NAME like {Name}
+    </select>
+    <select id="SelectCityAge" paramType="{Name:string}" resultType="{Id:int,Age:int,CreateTime:time}">
+        SELECT id, age, create_time FROM CITY WHERE NAME = {Name}
+    </select>
+    <select id="SelectCityPage">
+        SELECT * FROM CITY WHERE STATUS = {Status=ACTIVE} AND NOTE = {Note?} AND AGE > {Age:int} LIMIT {Limit=100:int}
+    </select>
+    <select id="selectAnyCity">
+        SELECT * FROM CITY
+    </select>
+    <select id="SelectCityWithIf">
+        SELECT id FROM city WHERE is_man={IsMan}
+        <if key="Name">
+        AND name={Name}
+        </if>
+    </select>
+</query>
+`)
+
+func TestGenerateCode(t *testing.T) {
+	generator := NewCodeGenerator("dao")
+	err := generator.AddXml(generatorData)
+	if err != nil {
+		t.Fatalf("fail to add xml : %s", err.Error())
+	}
+
+	var buffer bytes.Buffer
+	err = generator.Generate(&buffer)
+	if err != nil {
+		t.Fatalf("fail to generate : %s", err.Error())
+	}
+
+	source := buffer.String()
+	expects := []string{
+		"package dao",
+		"StmtDropCityTable ",
+		`"update_city_with_name"`,
+		"func DropCityTable(ex queryman.StatementExecutor) (sql.Result, error) {",
+		"func InsertCity(ex queryman.StatementExecutor, param *City) (sql.Result, error) {",
+		"return ex.ExecuteWithStmt(StmtInsertCity, param)",
+		"func UpdateCity(ex queryman.StatementExecutor, args ...interface{}) (sql.Result, error) {",
+		"func UpdateCityWithName(ex queryman.StatementExecutor, age interface{}, name interface{}, type_ interface{}) (sql.Result, error) {",
+		`return ex.ExecuteWithStmt(StmtUpdateCityWithName, map[string]interface{}{"Age": age, "Name": name, "type": type_})`,
+		"func SelectCityWithName(ex queryman.StatementExecutor, name interface{}) ([]City, error) {",
+		"type SelectCityAgeResult struct {",
+		"CreateTime time.Time",
+		"func SelectCityAge(ex queryman.StatementExecutor, name string) ([]SelectCityAgeResult, error) {",
+		"func SelectCityPage(ex queryman.StatementExecutor, status interface{}, note interface{}, age int64, limit interface{}) *queryman.QueryResult {",
+		`params := map[string]interface{}{"Age": age}`,
+		`params["Limit"] = limit`,
+		"return ex.QueryWithStmt(StmtSelectCityPage, params)",
+		"func SelectAnyCity(ex queryman.StatementExecutor) *queryman.QueryResult {",
+		"func SelectCityWithIf(ex queryman.StatementExecutor, params map[string]interface{}) *queryman.QueryResult {",
+	}
+
+	for _, v := range expects {
+		if !strings.Contains(source, v) {
+			t.Errorf("generated source does not contain [%s]\n%s", v, source)
+		}
+	}
+}
+
+func TestGenerateDuplicatedId(t *testing.T) {
+	generator := NewCodeGenerator("dao")
+	err := generator.AddXml(generatorData)
+	if err != nil {
+		t.Fatalf("fail to add xml : %s", err.Error())
+	}
+
+	err = generator.AddXml(generatorData)
+	if err == nil {
+		t.Fatalf("duplicated statement id is not reported")
+	}
+}
+
+func TestGenerateNameCollision(t *testing.T) {
+	for _, data := range []string{
+		`<query>
+		<select id="selectUser" resultType="{Id:int}">SELECT id FROM user</select>
+		<select id="selectUserResult">SELECT id FROM user</select>
+		</query>`,
+		`<query><select id="2ndQuery">SELECT id FROM user</select></query>`,
+		`<query><update id="UpdateUser">UPDATE user SET age=1</update><update id="update_user">UPDATE user SET age=2</update></query>`,
+	} {
+		generator := NewCodeGenerator("dao")
+		if err := generator.AddXml([]byte(data)); err != nil {
+			t.Fatalf("fail to add xml : %s", err.Error())
+		}
+		var buffer bytes.Buffer
+		if err := generator.Generate(&buffer); err == nil {
+			t.Errorf("generated names should be rejected : %s\n%s", data, buffer.String())
+		}
+	}
+}
+
+var caseOnlyParamData = []byte(`<query>
+	<update id="updateUserName">UPDATE user SET name={Name} WHERE name={name}</update>
+	</query>`)
+
+func TestGenerateParamNameCollision(t *testing.T) {
+	generator := NewCodeGenerator("dao")
+	if err := generator.AddXml(caseOnlyParamData); err != nil {
+		t.Fatalf("fail to add xml : %s", err.Error())
+	}
+	var buffer bytes.Buffer
+	if err := generator.Generate(&buffer); err != nil {
+		t.Fatalf("fail to generate : %s", err.Error())
+	}
+	expected := "func UpdateUserName(ex queryman.StatementExecutor, name interface{}, name2 interface{}) (sql.Result, error)"
+	if !strings.Contains(buffer.String(), expected) {
+		t.Fatalf("parameters differing only in case should be numbered :\n%s", buffer.String())
+	}
+}
+
+// TestGenerateCodeVet vets generated source in a temporary package of this module
+func TestGenerateCodeVet(t *testing.T) {
+	goTool, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("go tool is not found")
+	}
+
+	dir, err := ioutil.TempDir(".", "gentest")
+	if err != nil {
+		t.Fatalf("fail to create temp dir : %s", err.Error())
+	}
+	defer os.RemoveAll(dir)
+
+	generator := NewCodeGenerator("dao")
+	if err = generator.AddXml(generatorData); err != nil {
+		t.Fatalf("fail to add xml : %s", err.Error())
+	}
+	if err = generator.AddXml(caseOnlyParamData); err != nil {
+		t.Fatalf("fail to add xml : %s", err.Error())
+	}
+	var buffer bytes.Buffer
+	if err = generator.Generate(&buffer); err != nil {
+		t.Fatalf("fail to generate : %s", err.Error())
+	}
+
+	city := "package dao\n\ntype City struct {\n\tId   int\n\tName string\n\tAge  int\n}\n"
+	if err = ioutil.WriteFile(filepath.Join(dir, "city.go"), []byte(city), 0644); err != nil {
+		t.Fatalf("fail to write : %s", err.Error())
+	}
+	if err = ioutil.WriteFile(filepath.Join(dir, "dao.go"), buffer.Bytes(), 0644); err != nil {
+		t.Fatalf("fail to write : %s", err.Error())
+	}
+
+	output, err := exec.Command(goTool, "vet", "./"+filepath.Base(dir)).CombinedOutput()
+	if err != nil {
+		t.Fatalf("generated source does not compile : %s\n%s", err.Error(), buffer.String())
+	}
+	if len(output) > 0 {
+		t.Logf("%s", output)
+	}
+}
@@ -2,7 +2,10 @@ module throosea.com/queryman
 
 require (
 	github.com/go-sql-driver/mysql v1.4.0
-	google.golang.org/appengine v1.2.0 // indirect
+	github.com/lib/pq v1.10.9
+	github.com/mattn/go-sqlite3 v1.14.22
 )
 
-go 1.13
+require google.golang.org/appengine v1.2.0 // indirect
+
+go 1.19
@@ -39,6 +39,16 @@ func convertAssign(dest, src interface{}) error {
 			}
 			*d = []byte(s)
 			return nil
+		case *time.Time:
+			if d == nil {
+				return ErrNilPtr
+			}
+			t, err := parseTimeString(s)
+			if err != nil {
+				return err
+			}
+			*d = t
+			return nil
 		}
 	case []byte:
 		switch d := dest.(type) {
@@ -66,6 +76,16 @@ func convertAssign(dest, src interface{}) error {
 			}
 			*d = s
 			return nil
+		case *time.Time:
+			if d == nil {
+				return ErrNilPtr
+			}
+			t, err := parseTimeString(string(s))
+			if err != nil {
+				return err
+			}
+			*d = t
+			return nil
 		}
 	case time.Time:
 		switch d := dest.(type) {
@@ -0,0 +1,250 @@
+//
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+//
+// @project queryman
+// @author agent
+// @date 2026. 10. 19. AM 12:12
+//
+
+package queryman
+
+import (
+	"fmt"
+	"strings"
+)
+
+// insertStatement is INSERT INTO table [(columns)] VALUES (values) suffix.
+// prefix, group and suffix split the query around the values group
+type insertStatement struct {
+	table   string
+	columns []string
+	values  []string
+	prefix  string
+	group   string
+	suffix  string
+}
+
+// parseInsert splits insert query with tokens of dialect, so that literal, comment
+// and nested parenthesis in columns or values are not mistaken
+func parseInsert(dialect Dialect, query string) (insertStatement, error) {
+	insert := insertStatement{}
+	masked, err := maskQuery(dialect, query)
+	if err != nil {
+		return insert, err
+	}
+
+	into := findKeyword(masked, "INTO", 0)
+	if into < 0 {
+		return insert, fmt.Errorf("INTO is not found in insert : %s", query)
+	}
+	tableEnd := into + len("INTO")
+	values, keyword := findValuesKeyword(masked, tableEnd)
+	selected := findKeyword(masked, "SELECT", tableEnd)
+	if selected >= 0 && (values < 0 || selected < values) {
+		return insert, fmt.Errorf("INSERT ... SELECT has no VALUES clause : %s", query)
+	}
+	if values < 0 {
+		return insert, fmt.Errorf("VALUES is not found in insert : %s", query)
+	}
+
+	columnOpen := strings.IndexByte(masked[tableEnd:values], '(')
+	if columnOpen < 0 {
+		insert.table = strings.TrimSpace(query[tableEnd:values])
+	} else {
+		columnOpen = tableEnd + columnOpen
+		insert.table = strings.TrimSpace(query[tableEnd:columnOpen])
+		columnClose := matchParen(masked, columnOpen)
+		if columnClose < 0 || columnClose > values {
+			return insert, fmt.Errorf("unbalanced parenthesis in column list : %s", query)
+		}
+		if len(strings.TrimSpace(masked[columnClose+1:values])) > 0 {
+			return insert, fmt.Errorf("unexpected token after column list : %s", query)
+		}
+		insert.columns = splitList(query, masked, columnOpen+1, columnClose)
+	}
+	if len(insert.table) == 0 {
+		return insert, fmt.Errorf("table is not found in insert : %s", query)
+	}
+
+	err = insert.splitValues(query, masked, values+len(keyword))
+	return insert, err
+}
+
+// parseMerge splits MERGE ... USING (VALUES (values)) ... built for upsert of SQL Server
+func parseMerge(dialect Dialect, query string) (insertStatement, error) {
+	insert := insertStatement{}
+	masked, err := maskQuery(dialect, query)
+	if err != nil {
+		return insert, err
+	}
+
+	using := findKeyword(masked, "USING", 0)
+	if using < 0 {
+		return insert, fmt.Errorf("USING is not found in merge : %s", query)
+	}
+	open := skipSpace(masked, using+len("USING"))
+	values, keyword := findValuesKeyword(masked, open+1)
+	if open >= len(masked) || masked[open] != '(' || values < 0 || skipSpace(masked, open+1) != values {
+		return insert, fmt.Errorf("merge source should be VALUES : %s", query)
+	}
+
+	err = insert.splitValues(query, masked, values+len(keyword))
+	return insert, err
+}
+
+// splitValues reads a values group from offset. only one group is accepted to be repeated by bulk
+func (insert *insertStatement) splitValues(query string, masked string, offset int) error {
+	valuesOpen := skipSpace(masked, offset)
+	if valuesOpen >= len(masked) || masked[valuesOpen] != '(' {
+		return fmt.Errorf("values group is not found : %s", query)
+	}
+	valuesClose := matchParen(masked, valuesOpen)
+	if valuesClose < 0 {
+		return fmt.Errorf("unbalanced parenthesis in values : %s", query)
+	}
+	if strings.HasPrefix(strings.TrimSpace(masked[valuesClose+1:]), ",") {
+		return fmt.Errorf("multiple values groups are declared : %s", query)
+	}
+
+	insert.values = splitList(query, masked, valuesOpen+1, valuesClose)
+	if len(insert.columns) > 0 && len(insert.columns) != len(insert.values) {
+		return fmt.Errorf("column count %d does not match value count %d : %s", len(insert.columns), len(insert.values), query)
+	}
+	insert.prefix = query[:valuesOpen]
+	insert.group = query[valuesOpen:valuesClose+1]
+	insert.suffix = query[valuesClose+1:]
+	return nil
+}
+
+// hasConflictClause checks insert which skips or updates conflicting rows. such rows get no new id
+func hasConflictClause(dialect Dialect, query string) bool {
+	l := newDialectLexer(dialect, query)
+	return l.containsKeywords("ON", "DUPLICATE", "KEY") ||
+		l.containsKeywords("ON", "CONFLICT") ||
+		l.containsKeywords("INSERT", "IGNORE") ||
+		l.containsKeywords("OR", "IGNORE") ||
+		l.containsKeywords("OR", "REPLACE")
+}
+
+// parseBulkInsertQuery splits insert (or merge of upsert) so that values group is repeated for bulk
+func parseBulkInsertQuery(dialect Dialect, query string) (BulkInsertQuery, error) {
+	var insert insertStatement
+	var err error
+	if strings.HasPrefix(strings.ToUpper(strings.TrimSpace(query)), "MERGE") {
+		insert, err = parseMerge(dialect, query)
+	} else {
+		insert, err = parseInsert(dialect, query)
+	}
+	if err != nil {
+		return BulkInsertQuery{}, fmt.Errorf("cannot rewrite to bulk insert. %s", err.Error())
+	}
+	return BulkInsertQuery{prefix: insert.prefix, values: insert.group, suffix: insert.suffix}, nil
+}
+
+// findValuesKeyword finds VALUES or VALUE (MySQL) keyword
+func findValuesKeyword(masked string, from int) (int, string) {
+	values := findKeyword(masked, "VALUES", from)
+	value := findKeyword(masked, "VALUE", from)
+	if value >= 0 && (values < 0 || value < values) {
+		return value, "VALUE"
+	}
+	return values, "VALUES"
+}
+
+func skipSpace(masked string, offset int) int {
+	for offset < len(masked) && isSpaceByte(masked[offset]) {
+		offset++
+	}
+	return offset
+}
+
+// maskQuery upper cases code and blanks out quoted literal and comment keeping offsets.
+// placeholder is filled with '_' so that it looks like identifier
+func maskQuery(dialect Dialect, query string) (string, error) {
+	tokens, err := newDialectLexer(dialect, query).tokenize()
+	if err != nil {
+		return "", err
+	}
+
+	var masked strings.Builder
+	for _, token := range tokens {
+		switch token.tokenType {
+		case sqlTokenCode :
+			masked.WriteString(strings.ToUpper(token.text))
+		case sqlTokenPlaceholder :
+			masked.WriteString(strings.Repeat("_", len(token.text)+2))
+		default :
+			masked.WriteString(strings.Repeat(" ", len(token.text)))
+		}
+	}
+	return masked.String(), nil
+}
+
+// findKeyword returns offset of keyword which is not a part of other identifier
+func findKeyword(masked string, keyword string, from int) int {
+	for from < len(masked) {
+		i := strings.Index(masked[from:], keyword)
+		if i < 0 {
+			return -1
+		}
+		start := from + i
+		end := start + len(keyword)
+		if (start == 0 || !isIdentifierByte(masked[start-1])) && (end >= len(masked) || !isIdentifierByte(masked[end])) {
+			return start
+		}
+		from = end
+	}
+	return -1
+}
+
+func matchParen(masked string, open int) int {
+	depth := 0
+	for i := open; i < len(masked); i++ {
+		switch masked[i] {
+		case '(' :
+			depth++
+		case ')' :
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// splitList splits query[start:end] by comma which is not nested in parenthesis
+func splitList(query string, masked string, start int, end int) []string {
+	list := make([]string, 0)
+	depth := 0
+	from := start
+	for i := start; i < end; i++ {
+		switch masked[i] {
+		case '(' :
+			depth++
+		case ')' :
+			depth--
+		case ',' :
+			if depth == 0 {
+				list = append(list, strings.TrimSpace(query[from:i]))
+				from = i + 1
+			}
+		}
+	}
+	return append(list, strings.TrimSpace(query[from:end]))
+}
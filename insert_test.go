@@ -0,0 +1,107 @@
+//
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+//
+// @project queryman
+// @author agent
+// @date 2026. 10. 19. AM 12:18
+//
+
+package queryman
+
+import (
+	"testing"
+)
+
+func TestParseBulkInsertQuery(t *testing.T) {
+	cases := []struct {
+		query  string
+		values string
+		suffix string
+	}{
+		{"INSERT INTO CITY(NAME, OLD_VALUES) VALUES (?, ?)", "(?, ?)", ""},
+		{"INSERT INTO CITY(NAME,CREATE_TIME) VALUES(?, NOW())", "(?, NOW())", ""},
+		{"INSERT INTO CITY(NAME,MEMO) VALUES(?, 'a ) b') ON DUPLICATE KEY UPDATE MEMO=VALUES(MEMO)", "(?, 'a ) b')", " ON DUPLICATE KEY UPDATE MEMO=VALUES(MEMO)"},
+		{"INSERT INTO CITY(NAME,AGE) VALUE (?, COALESCE(?, 0) + 1)", "(?, COALESCE(?, 0) + 1)", ""},
+		{"INSERT INTO CITY VALUES (?, ?) -- VALUES (x)", "(?, ?)", " -- VALUES (x)"},
+		{"MERGE INTO CITY t USING (VALUES (?, ?)) s (NAME, AGE) ON (t.NAME = s.NAME) " +
+			"WHEN NOT MATCHED THEN INSERT (NAME, AGE) VALUES (s.NAME, s.AGE);", "(?, ?)",
+			") s (NAME, AGE) ON (t.NAME = s.NAME) WHEN NOT MATCHED THEN INSERT (NAME, AGE) VALUES (s.NAME, s.AGE);"},
+	}
+
+	for _, c := range cases {
+		query, err := parseBulkInsertQuery(mysqlDialect{}, c.query)
+		if err != nil {
+			t.Fatalf("fail to parse %s : %s", c.query, err.Error())
+		}
+		if query.values != c.values || query.suffix != c.suffix || query.prefix+query.values+query.suffix != c.query {
+			t.Errorf("invalid bulk query of %s : %s", c.query, query)
+		}
+	}
+
+	for _, v := range []string{
+		"INSERT INTO CITY(NAME) SELECT NAME FROM TOWN",
+		"INSERT INTO CITY(NAME) VALUES (?), (?)",
+		"INSERT INTO CITY(NAME, AGE) VALUES (?)",
+		"INSERT INTO CITY(NAME VALUES (?)",
+		"INSERT INTO CITY(NAME) VALUES (?, NOW()",
+		"INSERT INTO CITY(NAME) VALUES ('unterminated)",
+		"UPDATE CITY SET NAME=?",
+	} {
+		if _, err := parseBulkInsertQuery(mysqlDialect{}, v); err == nil {
+			t.Errorf("bulk rewriting should be failed : %s", v)
+		}
+	}
+}
+
+func TestParseInsert(t *testing.T) {
+	insert, err := parseInsert(postgresDialect{}, `INSERT INTO "city" ("name", age) VALUES ($1, $tag$a,b$tag$ || $2)`)
+	if err != nil {
+		t.Fatalf("fail to parse : %s", err.Error())
+	}
+	if insert.table != `"city"` || len(insert.columns) != 2 || insert.columns[0] != `"name"` {
+		t.Fatalf("invalid columns : %v", insert)
+	}
+	if len(insert.values) != 2 || insert.values[1] != "$tag$a,b$tag$ || $2" {
+		t.Fatalf("invalid values : %v", insert.values)
+	}
+}
+
+func TestConflictClause(t *testing.T) {
+	for _, v := range []string{
+		"INSERT INTO CITY(ID,NAME) VALUES (?,?) ON DUPLICATE KEY UPDATE NAME=VALUES(NAME)",
+		"INSERT IGNORE INTO CITY(ID,NAME) VALUES (?,?)",
+		"insert or ignore into CITY(ID,NAME) values (?,?)",
+		"INSERT OR REPLACE INTO CITY(ID,NAME) VALUES (?,?)",
+		"INSERT INTO CITY(ID,NAME) VALUES (?,?) ON CONFLICT (ID) DO NOTHING",
+	} {
+		if !hasConflictClause(mysqlDialect{}, v) {
+			t.Errorf("conflict clause should be found : %s", v)
+		}
+	}
+
+	for _, v := range []string{
+		"INSERT INTO CITY(ID,NAME) VALUES (?,'ON DUPLICATE KEY')",
+		"INSERT INTO CITY(ID,NAME) VALUES (?,?) /* ON CONFLICT */",
+		"INSERT INTO IGNORED(ID,NAME) VALUES (?,?)",
+		"INSERT INTO CITY(ID,NAME) SELECT ID, NAME FROM CITY_ON WHERE CONFLICT = ?",
+	} {
+		if hasConflictClause(mysqlDialect{}, v) {
+			t.Errorf("conflict clause should not be found : %s", v)
+		}
+	}
+}
@@ -0,0 +1,321 @@
+//
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+//
+// @project queryman
+// @author agent
+// @date 2026. 10. 18. PM 11:24
+//
+
+package queryman
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	sqlTokenCode = iota
+	sqlTokenQuoted
+	sqlTokenComment
+	sqlTokenPlaceholder
+)
+
+type sqlTokenType uint8
+
+// sqlToken is a fragment of query text.
+// inClause is set on placeholder which is the first element of IN (...) list
+type sqlToken struct {
+	tokenType sqlTokenType
+	text      string
+	inClause  bool
+}
+
+// sqlLexer splits query into code, quoted literal, comment and placeholder tokens.
+// placeholders and IN contexts are detected only in code positions
+type sqlLexer struct {
+	query           string
+	pos             int
+	backslashEscape bool
+	dollarQuote     bool
+	tokens          []sqlToken
+	code            strings.Builder
+	lastWord        string
+	lastSignificant byte
+	openInClause    bool
+}
+
+func newSqlLexer(query string, backslashEscape bool, dollarQuote bool) *sqlLexer {
+	l := &sqlLexer{}
+	l.query = query
+	l.backslashEscape = backslashEscape
+	l.dollarQuote = dollarQuote
+	l.tokens = make([]sqlToken, 0)
+	return l
+}
+
+func (l *sqlLexer) tokenize() ([]sqlToken, error) {
+	queryLen := len(l.query)
+	for l.pos < queryLen {
+		ch := l.query[l.pos]
+		var err error
+		switch {
+		case ch == '\'' || ch == '"' || ch == '`' :
+			err = l.scanQuoted(ch)
+		case ch == '-' && l.peek(1) == '-' :
+			l.scanLineComment()
+		case ch == '/' && l.peek(1) == '*' :
+			err = l.scanBlockComment()
+		case ch == '$' && l.dollarQuote && l.isDollarQuoteStart() :
+			err = l.scanDollarQuoted()
+		case ch == delimStartCharacter :
+			err = l.scanPlaceholder()
+		default :
+			l.scanCode(ch)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	l.flushCode()
+	return l.tokens, nil
+}
+
+func (l *sqlLexer) peek(offset int) byte {
+	if l.pos+offset >= len(l.query) {
+		return 0
+	}
+	return l.query[l.pos+offset]
+}
+
+func (l *sqlLexer) flushCode() {
+	if l.code.Len() == 0 {
+		return
+	}
+	l.tokens = append(l.tokens, sqlToken{tokenType: sqlTokenCode, text: l.code.String()})
+	l.code.Reset()
+}
+
+func (l *sqlLexer) addToken(tokenType sqlTokenType, start int) {
+	l.flushCode()
+	l.tokens = append(l.tokens, sqlToken{tokenType: tokenType, text: l.query[start:l.pos]})
+}
+
+func (l *sqlLexer) scanCode(ch byte) {
+	start := l.pos
+	if isIdentifierByte(ch) {
+		for l.pos < len(l.query) && isIdentifierByte(l.query[l.pos]) {
+			l.pos++
+		}
+		l.code.WriteString(l.query[start:l.pos])
+		l.lastWord = strings.ToUpper(l.query[start:l.pos])
+		l.lastSignificant = ch
+		l.openInClause = false
+		return
+	}
+
+	l.pos++
+	l.code.WriteByte(ch)
+	if isSpaceByte(ch) {
+		return
+	}
+
+	l.openInClause = ch == '(' && l.lastWord == "IN" && isIdentifierByte(l.lastSignificant)
+	l.lastSignificant = ch
+	if ch != '(' {
+		l.lastWord = ""
+	}
+}
+
+func (l *sqlLexer) scanQuoted(quote byte) error {
+	start := l.pos
+	escape := quote != '`' && (l.backslashEscape || l.isEscapeString(quote))
+	l.pos++
+	for l.pos < len(l.query) {
+		ch := l.query[l.pos]
+		if ch == '\\' && escape {
+			l.pos += 2
+			continue
+		}
+		if ch == quote {
+			if l.peek(1) == quote {
+				l.pos += 2
+				continue
+			}
+			l.pos++
+			l.addToken(sqlTokenQuoted, start)
+			l.markLiteral()
+			return nil
+		}
+		l.pos++
+	}
+
+	return fmt.Errorf("unterminated quoted literal : %s", l.query[start:])
+}
+
+// isEscapeString checks PostgreSQL E'...' literal
+func (l *sqlLexer) isEscapeString(quote byte) bool {
+	if quote != '\'' || l.pos < 1 {
+		return false
+	}
+	prefix := l.query[l.pos-1]
+	if prefix != 'E' && prefix != 'e' {
+		return false
+	}
+	return l.pos < 2 || !isIdentifierByte(l.query[l.pos-2])
+}
+
+func (l *sqlLexer) scanLineComment() {
+	start := l.pos
+	for l.pos < len(l.query) && l.query[l.pos] != '\n' {
+		l.pos++
+	}
+	l.addToken(sqlTokenComment, start)
+}
+
+func (l *sqlLexer) scanBlockComment() error {
+	start := l.pos
+	stop := strings.Index(l.query[l.pos+2:], "*/")
+	if stop < 0 {
+		return fmt.Errorf("unterminated comment : %s", l.query[start:])
+	}
+	l.pos = l.pos + 2 + stop + 2
+	l.addToken(sqlTokenComment, start)
+	return nil
+}
+
+// isDollarQuoteStart checks $$ or $tag$. $1 style positional parameter is not a quote
+func (l *sqlLexer) isDollarQuoteStart() bool {
+	if l.pos > 0 && isIdentifierByte(l.query[l.pos-1]) {
+		return false
+	}
+	return len(l.dollarTag()) > 0
+}
+
+func (l *sqlLexer) dollarTag() string {
+	i := l.pos + 1
+	if i < len(l.query) && l.query[i] >= '0' && l.query[i] <= '9' {
+		return ""
+	}
+	for i < len(l.query) && l.query[i] != '$' && isIdentifierByte(l.query[i]) {
+		i++
+	}
+	if i >= len(l.query) || l.query[i] != '$' {
+		return ""
+	}
+	return l.query[l.pos : i+1]
+}
+
+func (l *sqlLexer) scanDollarQuoted() error {
+	start := l.pos
+	tag := l.dollarTag()
+	stop := strings.Index(l.query[l.pos+len(tag):], tag)
+	if stop < 0 {
+		return fmt.Errorf("unterminated dollar quoted literal : %s", l.query[start:])
+	}
+	l.pos = l.pos + len(tag) + stop + len(tag)
+	l.addToken(sqlTokenQuoted, start)
+	l.markLiteral()
+	return nil
+}
+
+func (l *sqlLexer) scanPlaceholder() error {
+	stopIndex := strings.IndexByte(l.query[l.pos+1:], '}')
+	if stopIndex < 1 {
+		return fmt.Errorf("incompleted variable closer : %s", l.query)
+	}
+
+	v := l.query[l.pos+1 : l.pos+1+stopIndex]
+	if strings.IndexByte(v, delimStartCharacter) >= 0 {
+		return fmt.Errorf("invalid variable declare format : %s", l.query)
+	}
+
+	l.pos = l.pos + stopIndex + 2
+	l.flushCode()
+	l.tokens = append(l.tokens, sqlToken{tokenType: sqlTokenPlaceholder, text: v, inClause: l.openInClause})
+	l.markLiteral()
+	return nil
+}
+
+func (l *sqlLexer) markLiteral() {
+	l.lastWord = ""
+	l.lastSignificant = 0
+	l.openInClause = false
+}
+
+func isIdentifierByte(ch byte) bool {
+	return ch == '_' || ch == '$' || ch >= 0x80 ||
+		(ch >= 'a' && ch <= 'z') || (ch >= 'A' && ch <= 'Z') || (ch >= '0' && ch <= '9')
+}
+
+func isSpaceByte(ch byte) bool {
+	return ch == ' ' || ch == '\t' || ch == '\r' || ch == '\n' || ch == '\f'
+}
+
+const (
+	keywordReturning = "RETURNING"
+	keywordOutput    = "OUTPUT"
+	keywordOrder     = "ORDER"
+)
+
+// containsKeyword checks keyword appears in code position
+func (l *sqlLexer) containsKeyword(keyword string) bool {
+	return l.containsKeywords(keyword)
+}
+
+// containsKeywords checks keywords appear in sequence in code position. e.g) ON DUPLICATE KEY
+func (l *sqlLexer) containsKeywords(keywords ...string) bool {
+	words, err := l.codeWords()
+	if err != nil {
+		return false
+	}
+
+	for i := 0; i+len(keywords) <= len(words); i++ {
+		matched := true
+		for j, keyword := range keywords {
+			if !strings.EqualFold(words[i+j], keyword) {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return true
+		}
+	}
+	return false
+}
+
+// codeWords returns words in code position. literal, comment or placeholder between words breaks sequence
+func (l *sqlLexer) codeWords() ([]string, error) {
+	tokens, err := l.tokenize()
+	if err != nil {
+		return nil, err
+	}
+
+	words := make([]string, 0)
+	for _, token := range tokens {
+		if token.tokenType != sqlTokenCode {
+			words = append(words, "")
+			continue
+		}
+		words = append(words, strings.FieldsFunc(token.text, func(r rune) bool {
+			return r < 0x80 && !isIdentifierByte(byte(r))
+		})...)
+	}
+	return words, nil
+}
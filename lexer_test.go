@@ -0,0 +1,84 @@
+//
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+//
+// @project queryman
+// @author agent
+// @date 2026. 10. 18. PM 11:24
+//
+
+package queryman
+
+import (
+	"testing"
+)
+
+func TestNormalizeSkipsLiteralAndComment(t *testing.T) {
+	stmt := buildTestStatement(t, "mysql", QueryStatement{Query: `SELECT '{"a":1}', "x{y}", `+"`c{d}`"+` FROM T -- {Ignored}
+WHERE NAME REGEXP '^a{2,3}$' /* {Ignored} */ AND ID={Id} AND MEMO='it''s {x}' AND P='a\'{b}'`})
+
+	if len(stmt.columnMention) != 1 || stmt.columnMention[0].Name() != "Id" {
+		t.Fatalf("invalid column mention : %v", stmt.columnMention)
+	}
+	if stmt.Query != `SELECT '{"a":1}', "x{y}", `+"`c{d}`"+` FROM T -- {Ignored}
+WHERE NAME REGEXP '^a{2,3}$' /* {Ignored} */ AND ID=? AND MEMO='it''s {x}' AND P='a\'{b}'` {
+		t.Fatalf("invalid query : %s", stmt.Query)
+	}
+}
+
+func TestNormalizeInClause(t *testing.T) {
+	stmt := buildTestStatement(t, "mysql", QueryStatement{Query: "SELECT * FROM T WHERE ID in /* ids */\n\t(\n\t\t{Ids}) AND NAME NOT IN({Names}) AND (A, {B}) AND MIN({C}) > 0"})
+
+	expected := []columnBindType{columnBindTypeArray, columnBindTypeArray, columnBindTypeNormal, columnBindTypeNormal}
+	if len(stmt.columnMention) != len(expected) {
+		t.Fatalf("invalid column mention : %v", stmt.columnMention)
+	}
+	for i, v := range expected {
+		if stmt.columnMention[i].bindType != v {
+			t.Errorf("invalid bind type of %s", stmt.columnMention[i].Name())
+		}
+	}
+}
+
+func TestNormalizeDollarQuote(t *testing.T) {
+	stmt := QueryStatement{}
+	stmt.Id = "DollarQuote"
+	stmt.Query = "SELECT $body${NotBind}$body$, $${x}$$, E'\\'{y}', '\\' AS P WHERE ID = {Id} AND NAME = {Name}"
+	err := newNormalizer("postgresql").normalize(&stmt)
+	if err != nil {
+		t.Fatalf("fail to normalize : %s", err.Error())
+	}
+	if len(stmt.columnMention) != 2 || stmt.columnMention[0].Name() != "Id" {
+		t.Fatalf("invalid column mention : %v", stmt.columnMention)
+	}
+}
+
+func TestNormalizeInvalid(t *testing.T) {
+	invalid := []string{
+		"SELECT * FROM T WHERE A = 'abc",
+		"SELECT * FROM T /* comment",
+		"SELECT * FROM T WHERE A = {Name",
+		"SELECT * FROM T WHERE A = {}",
+	}
+
+	for _, v := range invalid {
+		stmt := QueryStatement{Id: "Invalid", Query: v}
+		if err := newNormalizer("mysql").normalize(&stmt); err == nil {
+			t.Errorf("expect error : %s", v)
+		}
+	}
+}
@@ -0,0 +1,149 @@
+//
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+//
+// @project queryman
+// @author agent
+// @date 2026. 10. 19. AM 12:38
+//
+
+// Package loaddata registers native bulk loader of MySQL (github.com/go-sql-driver/mysql)
+// which streams rows into LOAD DATA LOCAL INFILE. server should allow local_infile
+//
+//	import _ "throosea.com/queryman/loaddata"
+package loaddata
+
+import (
+	"bufio"
+	"database/sql/driver"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	"throosea.com/queryman"
+)
+
+var loadDataSeq int64
+
+func init() {
+	queryman.RegisterNativeLoader("mysql", loader{})
+}
+
+// loader streams rows as CSV with reader handler of mysql driver
+type loader struct {
+}
+
+func (l loader) Supports(drv driver.Driver) bool {
+	_, ok := drv.(*mysql.MySQLDriver)
+	return ok
+}
+
+func (l loader) Load(conn queryman.NativeConn, table string, columns []string, rows [][]interface{}) (int64, error) {
+	name := fmt.Sprintf("queryman_%d", atomic.AddInt64(&loadDataSeq, 1))
+	reader, writer := io.Pipe()
+	mysql.RegisterReaderHandler(name, func() io.Reader {
+		return reader
+	})
+	defer mysql.DeregisterReaderHandler(name)
+
+	go func() {
+		writer.CloseWithError(writeLoadData(writer, rows))
+	}()
+
+	query := fmt.Sprintf("LOAD DATA LOCAL INFILE 'Reader::%s' INTO TABLE %s CHARACTER SET utf8mb4 "+
+		"FIELDS TERMINATED BY ',' OPTIONALLY ENCLOSED BY '\"' ESCAPED BY '\\\\' LINES TERMINATED BY '\\n' (%s)",
+		name, table, strings.Join(columns, ", "))
+	loaded, err := conn.Exec(query)
+	// unblock writer when the driver stops reading
+	reader.CloseWithError(io.ErrClosedPipe)
+	if err != nil {
+		return 0, err
+	}
+	return loaded.RowsAffected()
+}
+
+func writeLoadData(w io.Writer, rows [][]interface{}) error {
+	buf := bufio.NewWriter(w)
+	for _, row := range rows {
+		for i, v := range row {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			if err := writeLoadDataField(buf, v); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte('\n')
+	}
+	return buf.Flush()
+}
+
+// writeLoadDataField writes NULL as \N and encloses others with '"' escaping by backslash
+func writeLoadDataField(buf *bufio.Writer, v interface{}) error {
+	value, err := driver.DefaultParameterConverter.ConvertValue(v)
+	if err != nil {
+		return err
+	}
+
+	var text string
+	switch p := value.(type) {
+	case nil :
+		buf.WriteString(`\N`)
+		return nil
+	case bool :
+		if p {
+			text = "1"
+		} else {
+			text = "0"
+		}
+	case int64 :
+		text = strconv.FormatInt(p, 10)
+	case float64 :
+		text = strconv.FormatFloat(p, 'g', -1, 64)
+	case time.Time :
+		text = p.Format("2006-01-02 15:04:05.999999")
+	case []byte :
+		text = string(p)
+	case string :
+		text = p
+	default :
+		text = fmt.Sprint(p)
+	}
+
+	buf.WriteByte('"')
+	for i := 0; i < len(text); i++ {
+		switch ch := text[i]; ch {
+		case '"', '\\' :
+			buf.WriteByte('\\')
+			buf.WriteByte(ch)
+		case 0 :
+			buf.WriteString(`\0`)
+		case '\n' :
+			buf.WriteString(`\n`)
+		case '\r' :
+			buf.WriteString(`\r`)
+		default :
+			buf.WriteByte(ch)
+		}
+	}
+	buf.WriteByte('"')
+	return nil
+}
@@ -0,0 +1,108 @@
+// +build mysql
+
+//
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+//
+// @project queryman
+// @author agent
+// @date 2026. 10. 19. AM 12:38
+//
+
+package loaddata
+
+import (
+	"context"
+	"flag"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"throosea.com/queryman"
+)
+
+// go test -v -tags mysql ./loaddata -mysqlsource="user:password@tcp(127.0.0.1:3306)/db"
+var mysqlSourceName = flag.String("mysqlsource", "mmate:angel@tcp(127.0.0.1:3306)/mmate", "mysql data source")
+
+var xmlSample = []byte(`
+<?xml version="1.0" encoding="UTF-8" ?>
+<query>
+	<update id="DropAlbumTable">
+		drop table if exists load_album
+	</update>
+	<update id="CreateAlbumTable">
+		create table load_album (id int, score int, primary key (id))
+	</update>
+	<insert id="InsertAlbum">
+		INSERT INTO load_album(id, score) VALUES({Id}, {Score})
+	</insert>
+	<select id="CountAlbum">
+		SELECT COUNT(*) FROM load_album
+	</select>
+</query>
+`)
+
+type AlbumData struct {
+	Id    int
+	Score int
+}
+
+func TestBulkLoadData(t *testing.T) {
+	tempDir, err := ioutil.TempDir("", "queryman")
+	if err != nil {
+		t.Fatalf("fail to create temp dir : %s", err.Error())
+	}
+	defer os.RemoveAll(tempDir)
+	if err = ioutil.WriteFile(filepath.Join(tempDir, "load.xml"), xmlSample, 0644); err != nil {
+		t.Fatalf("fail to prepare sample xml file : %s", err.Error())
+	}
+
+	pref := queryman.NewQuerymanPreference(tempDir, *mysqlSourceName)
+	pref.Fileset = "load.xml"
+	man, err := queryman.NewQueryman(pref)
+	if err != nil {
+		t.Fatalf("fail to create queryman : %s", err.Error())
+	}
+	defer man.Close()
+	man.ExecuteWithStmt("DropAlbumTable")
+	if _, err = man.ExecuteWithStmt("CreateAlbumTable"); err != nil {
+		t.Fatalf("fail to create table : %s", err.Error())
+	}
+
+	opts := queryman.NewBulkOptions()
+	opts.Mode = queryman.BulkModeNative
+	bulk, err := man.CreateBulkWithOptions(context.Background(), "InsertAlbum", opts)
+	if err != nil {
+		t.Fatalf("fail to create bulk : %s", err.Error())
+	}
+	for i := 1; i <= 1000; i++ {
+		bulk.AddBatch(AlbumData{Id: i, Score: i % 100})
+	}
+	result, err := bulk.Execute()
+	if err != nil {
+		t.Fatalf("fail to load data : %s", err.Error())
+	}
+	if affected, _ := result.RowsAffected(); affected != 1000 {
+		t.Fatalf("with %d, but %d", 1000, affected)
+	}
+
+	count := 0
+	if err = man.QueryRowWithStmt("CountAlbum").Scan(&count); err != nil || count != 1000 {
+		t.Fatalf("with %d, but %d", 1000, count)
+	}
+}
@@ -0,0 +1,56 @@
+//
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+//
+// @project queryman
+// @author agent
+// @date 2026. 10. 19. AM 12:38
+//
+
+package loaddata
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/go-sql-driver/mysql"
+)
+
+func TestWriteLoadData(t *testing.T) {
+	rows := [][]interface{}{
+		{"seoul", 10, nil},
+		{"say \"hi\"\n\\", true, []byte("a,b")},
+	}
+
+	var buf bytes.Buffer
+	if err := writeLoadData(&buf, rows); err != nil {
+		t.Fatalf("fail to write load data : %s", err.Error())
+	}
+	expected := "\"seoul\",\"10\",\\N\n\"say \\\"hi\\\"\\n\\\\\",\"1\",\"a,b\"\n"
+	if buf.String() != expected {
+		t.Fatalf("invalid load data : %q", buf.String())
+	}
+}
+
+func TestSupports(t *testing.T) {
+	if !(loader{}).Supports(&mysql.MySQLDriver{}) {
+		t.Fatalf("loader should support mysql driver")
+	}
+	if (loader{}).Supports(nil) {
+		t.Fatalf("loader should support mysql driver only")
+	}
+}
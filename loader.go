@@ -87,6 +87,9 @@ type QuerymanPreference struct {
 	SlowQueryDuration time.Duration
 	SlowQueryFunc     func(stmtId string, start time.Time, elapsed time.Duration)
 	fieldNameConvert  fieldNameConvertMethod
+	replicaSourceUrls []string
+	ReplicaBalance    ReplicaBalance
+	ReplicaHealthCheckInterval time.Duration
 }
 
 func NewQuerymanPreference(filepath string, dataSourceUrl string) QuerymanPreference {
@@ -102,14 +105,23 @@ func NewQuerymanPreference(filepath string, dataSourceUrl string) QuerymanPrefer
 	pref.SlowQueryDuration = 0
 	pref.DebugLogger = defaultLogger{}
 	pref.fieldNameConvert = fieldNameConvertToCamel
+	pref.ReplicaBalance = ReplicaRoundRobin
+	pref.ReplicaHealthCheckInterval = time.Duration(time.Second * 10)
 
 	return pref
 }
 
+// AddReplica adds read replica. select statements are routed to replicas
+func (pref *QuerymanPreference) AddReplica(dataSourceUrl string) {
+	pref.replicaSourceUrls = append(pref.replicaSourceUrls, dataSourceUrl)
+}
+
 func NewQueryman(pref QuerymanPreference) (*QueryMan, error) {
 	manager := &QueryMan{}
 	manager.preference = pref
 	manager.statementMap = make(map[string]QueryStatement)
+	manager.variantSet = make(map[string]bool)
+	manager.normalizer = newNormalizer(pref.DriverName)
 
 	db, err := sql.Open(pref.DriverName, pref.dataSourceUrl)
 	if err != nil {
@@ -124,9 +136,19 @@ func NewQueryman(pref QuerymanPreference) (*QueryMan, error) {
 
 	err = loadXmlFile(manager, pref.queryFilePath, pref.Fileset)
 	if err != nil {
+		manager.db.Close()
 		return nil, fmt.Errorf("fail to load xml file : %s [path=%s,fileset=%s]", err.Error(), pref.queryFilePath, pref.Fileset)
 	}
 
+	// replicas are opened after xml is loaded so that health check is not left running on failure
+	if len(pref.replicaSourceUrls) > 0 {
+		manager.replicas, err = newReplicaSet(pref)
+		if err != nil {
+			manager.db.Close()
+			return nil, fmt.Errorf("fail to open replica : %s", err.Error())
+		}
+	}
+
 	runtime.SetFinalizer(manager, closeQueryman)
 
 	if manager.preference.SlowQueryDuration > 0 && manager.preference.SlowQueryFunc != nil {
@@ -185,6 +207,23 @@ func loadXmlFile(manager *QueryMan, filePath string, fileSet string) error {
 }
 
 func loadWithSax(manager *QueryMan, data []byte) error {
+	list, err := parseStatements(data)
+	if err != nil {
+		return err
+	}
+
+	for _, v := range list {
+		err := manager.registStatement(v)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// parseStatements reads sql statements from xml data without registering them
+func parseStatements(data []byte) ([]QueryStatement, error) {
 	stmtList = make([]QueryStatement, 0)
 	buf := bytes.NewBuffer(data)
 	dec := xml.NewDecoder(buf)
@@ -195,7 +234,7 @@ func loadWithSax(manager *QueryMan, data []byte) error {
 			if tokenErr == io.EOF {
 				break
 			}
-			return tokenErr
+			return nil, tokenErr
 		}
 
 		switch t := t.(type) {
@@ -204,6 +243,15 @@ func loadWithSax(manager *QueryMan, data []byte) error {
 			currentEleType = buildElementType(t.Name.Local)
 			if currentEleType.IsSql()	{
 				currentStmt = newQueryStatement(currentEleType)
+				currentStmt.ParamType = getAttr(t.Attr, attrParamType)
+				currentStmt.ResultType = getAttr(t.Attr, attrResultType)
+				currentStmt.KeyColumn = getAttr(t.Attr, attrKeyColumn)
+				currentStmt.KeyProperty = getAttr(t.Attr, attrKeyProperty)
+				currentStmt.DatabaseId = getAttr(t.Attr, attrDatabaseId)
+				currentStmt.UseMaster = strings.ToLower(getAttr(t.Attr, attrUseMaster)) == "true"
+				currentStmt.upsert = strings.EqualFold(t.Name.Local, elementUpsert)
+				currentStmt.ConflictKey = getAttr(t.Attr, attrConflictKey)
+				currentStmt.UpdateColumns = getAttr(t.Attr, attrUpdateColumns)
 				traverseIf(dec)
 			}
 		case xml.CharData:
@@ -219,14 +267,7 @@ func loadWithSax(manager *QueryMan, data []byte) error {
 		}
 	}
 
-	for _, v := range stmtList {
-		err := manager.registStatement(v)
-		if err != nil {
-			return err
-		}
-	}
-
-	return nil
+	return stmtList, nil
 }
 
 func newQueryStatement(sqlType declareElementType)	QueryStatement	{
@@ -242,6 +283,15 @@ const (
 	attrId  = "id"
 	attrKey = "key"
 	attrExist = "exist"
+	attrParamType = "paramType"
+	attrResultType = "resultType"
+	attrKeyColumn = "keyColumn"
+	attrKeyProperty = "keyProperty"
+	attrDatabaseId = "databaseId"
+	attrUseMaster = "useMaster"
+	attrConflictKey = "conflictKey"
+	attrUpdateColumns = "updateColumns"
+	elementUpsert = "upsert"
 	cutset  = "\r\t\n "
 )
 
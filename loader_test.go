@@ -49,6 +49,7 @@ var testData = []byte(`
 // go test -v -db=local -user=local -password=angel -host=127.0.0.1:3306
 func TestLoaderSimple(t *testing.T) {
 	queryNormalizer = newNormalizer("mysql")
+	ifClauseSeq = 0
 
 	stmtList = make([]QueryStatement, 0)
 	buf := bytes.NewBuffer(testData)
@@ -0,0 +1,134 @@
+//
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+//
+// @project queryman
+// @author agent
+// @date 2026. 10. 18. PM 11:44
+//
+
+package queryman
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+)
+
+var (
+	errNoMoreData = errors.New("no more data")
+)
+
+// newTestQueryman returns QueryMan of driverName without database. statements are registered by test
+func newTestQueryman(driverName string) *QueryMan {
+	man := &QueryMan{}
+	man.preference = NewQuerymanPreference("", "")
+	man.preference.DriverName = driverName
+	man.statementMap = make(map[string]QueryStatement)
+	return man
+}
+
+// registTestXml registers statements of xmlText to QueryMan of driverName
+func registTestXml(driverName string, xmlText string) (*QueryMan, error) {
+	man := newTestQueryman(driverName)
+	list, err := parseStatements([]byte(xmlText))
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range list {
+		if err = man.registStatement(v); err != nil {
+			return nil, err
+		}
+	}
+	return man, nil
+}
+
+// buildTestStatement builds stmt as loaded from xml for driverName.
+// Id is TestStmt and element type is taken from query when they are not given
+func buildTestStatement(t *testing.T, driverName string, stmt QueryStatement) QueryStatement {
+	if len(stmt.Id) == 0 {
+		stmt.Id = "TestStmt"
+	}
+	if stmt.eleType == eleTypeUnknown {
+		stmt.eleType = getDeclareSqlType(stmt.Query)
+	}
+	built, err := newTestQueryman(driverName).buildStatement(stmt)
+	if err != nil {
+		t.Fatalf("fail to build statement : %s", err.Error())
+	}
+	return built
+}
+
+type testSuite struct {
+	prepare  func() error
+	teardown func()
+}
+
+var testSuites = make([]testSuite, 0)
+
+// registerTestSuite is called from init() of database specific test files
+func registerTestSuite(prepare func() error, teardown func()) {
+	testSuites = append(testSuites, testSuite{prepare: prepare, teardown: teardown})
+}
+
+// go test -v
+// go test -v -tags mysql -db=local -user=local -password=angel -host=127.0.0.1:3306
+func TestMain(m *testing.M) {
+	flag.Parse()
+
+	for _, suite := range testSuites {
+		if err := suite.prepare(); err != nil {
+			fmt.Printf("%s\n", err.Error())
+			os.Exit(1)
+		}
+	}
+
+	code := m.Run()
+	for _, suite := range testSuites {
+		suite.teardown()
+	}
+	os.Exit(code)
+}
+
+type City struct {
+	Id		int
+	Name	string
+	Age		int
+	IsMan	bool
+	Percentage float32
+	CreateTime time.Time
+	UpdateTime time.Time
+}
+
+func createCity() City {
+	city := City{}
+	city.Name = "jin.freestyle@gmail.com"
+	city.Age = 142
+	city.IsMan = true
+	city.Percentage = 43.4
+	city.CreateTime = time.Now()
+	city.UpdateTime = time.Now()
+	return city
+}
+
+type AlbumData struct {
+	Id 	int
+	Score int
+}
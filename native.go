@@ -0,0 +1,161 @@
+//
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+//
+// @project queryman
+// @author agent
+// @date 2026. 10. 19. AM 12:38
+//
+
+package queryman
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"strings"
+	"sync"
+	"time"
+)
+
+// NativeLoader loads rows of bulk insert with native protocol of a driver (BulkModeNative).
+// loaders are registered by optional packages so that queryman does not depend on any driver
+//
+//	import _ "throosea.com/queryman/loaddata"
+type NativeLoader interface {
+	// Supports reports whether the loader works on drv
+	Supports(drv driver.Driver) bool
+	// Load loads rows into columns of table and returns the number of loaded rows
+	Load(conn NativeConn, table string, columns []string, rows [][]interface{}) (int64, error)
+}
+
+// NativeConn runs statements of NativeLoader in a transaction
+type NativeConn interface {
+	Exec(query string, args ...interface{}) (sql.Result, error)
+	Prepare(query string) (*sql.Stmt, error)
+}
+
+var loaderRegistry = struct {
+	sync.RWMutex
+	loaders map[string]NativeLoader
+}{loaders: make(map[string]NativeLoader)}
+
+// RegisterNativeLoader registers loader for dialect name (mysql, postgres, ...). nil loader unregisters
+func RegisterNativeLoader(dialectName string, loader NativeLoader) {
+	loaderRegistry.Lock()
+	defer loaderRegistry.Unlock()
+	if loader == nil {
+		delete(loaderRegistry.loaders, dialectName)
+		return
+	}
+	loaderRegistry.loaders[dialectName] = loader
+}
+
+// driverProxy reports driver of the database which proxy runs on
+type driverProxy interface {
+	driver() driver.Driver
+}
+
+// findNativeLoader returns registered loader of dialect which supports driver of sqlProxy
+func findNativeLoader(dialect Dialect, sqlProxy SqlProxy) (NativeLoader, bool) {
+	loaderRegistry.RLock()
+	loader, ok := loaderRegistry.loaders[dialect.Name()]
+	loaderRegistry.RUnlock()
+	if !ok {
+		return nil, false
+	}
+
+	p, ok := sqlProxy.(driverProxy)
+	if !ok || p.driver() == nil || !loader.Supports(p.driver()) {
+		return nil, false
+	}
+	return loader, true
+}
+
+type nativeConn struct {
+	sqlProxy SqlProxy
+}
+
+func (c nativeConn) Exec(query string, args ...interface{}) (sql.Result, error) {
+	return c.sqlProxy.exec(query, args...)
+}
+
+func (c nativeConn) Prepare(query string) (*sql.Stmt, error) {
+	return c.sqlProxy.prepare(query)
+}
+
+// executeNative runs bulk insert with registered loader of dialect in a transaction.
+// false is returned when no loader is available or the statement is not supported
+func (b *querymanBulk) executeNative(dialect Dialect) (sql.Result, bool, error) {
+	insert, ok := b.nativeInsert(dialect)
+	if !ok {
+		return nil, false, nil
+	}
+	loader, ok := findNativeLoader(dialect, b.sqlProxy)
+	if !ok {
+		return nil, false, nil
+	}
+
+	result := ExecMultiResult{}
+	if b.execCount == 0 {
+		return result, true, nil
+	}
+
+	rows := make([][]interface{}, 0, len(b.rowSizes))
+	offset := 0
+	for _, size := range b.rowSizes {
+		rows = append(rows, b.params[offset:offset+size])
+		offset += size
+	}
+
+	if b.sqlProxy.debugEnabled() {
+		b.sqlProxy.debugPrint("bulk native [%s] : %d rows", b.stmt.Id, b.execCount)
+	}
+	start := time.Now()
+	err := runInBatch(b.sqlProxy, func(sqlProxy SqlProxy) error {
+		loaded, err := loader.Load(nativeConn{sqlProxy}, insert.table, insert.columns, rows)
+		result.rowAffected = loaded
+		return err
+	})
+	b.sqlProxy.recordExcution(b.stmt.Id, start)
+	if err != nil {
+		return nil, true, err
+	}
+
+	result.chunkRows = append(result.chunkRows, int64(b.execCount))
+	return result, true, nil
+}
+
+// nativeInsert checks that each value of insert is a plain placeholder,
+// so that columns are bound in order of columnMention
+func (b *querymanBulk) nativeInsert(dialect Dialect) (insertStatement, bool) {
+	if b.stmt.hasArrayBind() || b.stmt.returning {
+		return insertStatement{}, false
+	}
+	insert, err := parseInsert(dialect, b.stmt.Query)
+	if err != nil || len(insert.columns) == 0 || len(strings.Trim(insert.suffix, "; \r\t\n")) > 0 {
+		return insertStatement{}, false
+	}
+	if len(insert.values) != len(b.stmt.columnMention) {
+		return insertStatement{}, false
+	}
+	for i, v := range insert.values {
+		if v != dialect.Placeholder(i+1, b.stmt.columnMention[i].name) {
+			return insertStatement{}, false
+		}
+	}
+	return insert, true
+}
@@ -0,0 +1,73 @@
+//
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+//
+// @project queryman
+// @author agent
+// @date 2026. 10. 19. AM 12:39
+//
+
+// Package pgcopy registers native bulk loader of PostgreSQL (github.com/lib/pq)
+// which streams rows with COPY ... FROM STDIN
+//
+//	import _ "throosea.com/queryman/pgcopy"
+package pgcopy
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"strings"
+
+	"github.com/lib/pq"
+	"throosea.com/queryman"
+)
+
+func init() {
+	queryman.RegisterNativeLoader("postgres", loader{})
+}
+
+// loader streams rows with COPY of lib/pq. statement is not quoted by pq.CopyIn
+// so that identifiers keep the case of insert statement
+type loader struct {
+}
+
+func (l loader) Supports(drv driver.Driver) bool {
+	_, ok := drv.(*pq.Driver)
+	return ok
+}
+
+func (l loader) Load(conn queryman.NativeConn, table string, columns []string, rows [][]interface{}) (int64, error) {
+	query := fmt.Sprintf("COPY %s (%s) FROM STDIN", table, strings.Join(columns, ", "))
+	pstmt, err := conn.Prepare(query)
+	if err != nil {
+		return 0, err
+	}
+	defer pstmt.Close()
+
+	for _, row := range rows {
+		_, err = pstmt.Exec(row...)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	copied, err := pstmt.Exec()
+	if err != nil {
+		return 0, err
+	}
+	return copied.RowsAffected()
+}
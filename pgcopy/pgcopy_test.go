@@ -0,0 +1,39 @@
+//
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+//
+// @project queryman
+// @author agent
+// @date 2026. 10. 19. AM 12:39
+//
+
+package pgcopy
+
+import (
+	"testing"
+
+	"github.com/lib/pq"
+)
+
+func TestSupports(t *testing.T) {
+	if !(loader{}).Supports(&pq.Driver{}) {
+		t.Fatalf("loader should support lib/pq driver")
+	}
+	if (loader{}).Supports(nil) {
+		t.Fatalf("loader should support lib/pq driver only")
+	}
+}
@@ -24,6 +24,7 @@
 package queryman
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"runtime"
@@ -35,35 +36,81 @@ var queryNormalizer         QueryNormalizer
 
 type QueryNormalizer interface {
 	normalize(stmt *QueryStatement) error
-	resolveHolding(query string) string
+	resolveHolding(query string, names []string) string
+	getDialect() Dialect
 }
 
 type QueryMan struct {
 	db                 *sql.DB
 	preference         QuerymanPreference
 	statementMap       map[string]QueryStatement
+	variantSet         map[string]bool
 	fieldNameConverter FieldNameConvertStrategy
 	execRecordChan 	   chan queryExecution
+	normalizer         QueryNormalizer
+	replicas           *replicaSet
+	useMaster          bool
+	parent             *QueryMan	// keeps owner of connections reachable while view is used
 }
 
 func (man *QueryMan) GetSqlCount() int {
 	return len(man.statementMap)
 }
 
+// Dialect returns sql dialect of the driver. it provides identifier quoting and pagination
+func (man *QueryMan) Dialect() Dialect {
+	if man.normalizer == nil {
+		man.normalizer = newNormalizer(man.preference.DriverName)
+	}
+	return man.normalizer.getDialect()
+}
+
+// UseMaster returns QueryMan which sends select statements to primary datasource, not to replicas.
+// it shares connections with man, so close man only. man is not finalized while the view is reachable
+func (man *QueryMan) UseMaster() *QueryMan {
+	view := *man
+	view.useMaster = true
+	if man.parent == nil {
+		view.parent = man
+	}
+	return &view
+}
+
 func (man *QueryMan) GetMaxConnCount() int {
 	return man.preference.MaxOpenConns
 }
 
 func (man *QueryMan) registStatement(queryStatement QueryStatement) error {
-	queryStatement, err := man.buildStatement(queryStatement)
+	id := strings.ToUpper(queryStatement.Id)
+	name, err := databaseIdName(queryStatement.DatabaseId)
 	if err != nil {
-		return err
+		return fmt.Errorf("stmt [%s] : %s", id, err.Error())
 	}
 
-	id := strings.ToUpper(queryStatement.Id)
-	if _, exists := man.statementMap[id]; exists {
+	if man.variantSet == nil {
+		man.variantSet = make(map[string]bool)
+	}
+	variant := id + "@" + name
+	if man.variantSet[variant] {
+		if len(name) > 0 {
+			return fmt.Errorf("duplicated user statement id : %s (databaseId %s)", id, name)
+		}
 		return fmt.Errorf("duplicated user statement id : %s", id)
 	}
+	man.variantSet[variant] = true
+
+	if len(name) > 0 && name != man.Dialect().Name() {
+		return nil
+	}
+	if exist, exists := man.statementMap[id]; exists && len(exist.DatabaseId) > 0 {
+		// dialect specific variant is already loaded
+		return nil
+	}
+
+	queryStatement, err = man.buildStatement(queryStatement)
+	if err != nil {
+		return err
+	}
 
 	man.statementMap[id] = queryStatement
 
@@ -75,19 +122,42 @@ func (man *QueryMan) registStatement(queryStatement QueryStatement) error {
 }
 
 func (man *QueryMan) buildStatement(queryStatement QueryStatement) (QueryStatement, error) {
+	if man.normalizer == nil {
+		man.normalizer = newNormalizer(man.preference.DriverName)
+	}
 	if queryNormalizer == nil {
-		queryNormalizer = newNormalizer(man.preference.DriverName)
-		if queryNormalizer == nil {
-			return queryStatement, fmt.Errorf("not found normalizer for %s", man.preference.DriverName)
-		}
+		queryNormalizer = man.normalizer
 	}
 
+	queryStatement.normalizer = man.normalizer
+	if queryStatement.upsert {
+		err := renderUpsert(&queryStatement, man.normalizer.getDialect())
+		if err != nil {
+			return queryStatement, err
+		}
+	}
 	if !queryStatement.HasCondition()	{
-		err := queryNormalizer.normalize(&queryStatement)
+		err := man.normalizer.normalize(&queryStatement)
 		if err != nil {
 			return queryStatement, err
 		}
 	}
+	err := prepareReturning(&queryStatement)
+	if err != nil {
+		return queryStatement, err
+	}
+
+	queryStatement.paramDecl, err = parseStatementType(queryStatement.ParamType)
+	if err != nil {
+		return queryStatement, fmt.Errorf("invalid paramType of stmt [%s] : %s", queryStatement.Id, err.Error())
+	}
+	if queryStatement.paramDecl == nil {
+		queryStatement.paramDecl = placeholderType(queryStatement)
+	}
+	queryStatement.resultDecl, err = parseStatementType(queryStatement.ResultType)
+	if err != nil {
+		return queryStatement, fmt.Errorf("invalid resultType of stmt [%s] : %s", queryStatement.Id, err.Error())
+	}
 
 	return queryStatement, nil
 }
@@ -97,6 +167,10 @@ func (man *QueryMan) Close() error {
 		man.execRecordChan <- queryExecution{close:true}
 		close(man.execRecordChan)
 	}
+	if man.replicas != nil {
+		man.replicas.close()
+	}
+	insertIdSteps.remove(man.db)
 
 	return man.db.Close()
 }
@@ -131,6 +205,13 @@ func (man *QueryMan) debugPrint(format string, params ...interface{})	{
 	}
 }
 
+// errorPrint reports failure which can not be returned, regardless of debug mode
+func (man *QueryMan) errorPrint(format string, params ...interface{})	{
+	if man.preference.DebugLogger != nil {
+		man.preference.DebugLogger.Printf(format, params...)
+	}
+}
+
 func (man *QueryMan) recordExcution(stmtId string, start time.Time)	{
 	if man.execRecordChan != nil {
 		man.execRecordChan <- newQueryExecution(stmtId, start)
@@ -193,6 +274,20 @@ func (man *QueryMan) CreateBulk() (Bulk, error) {
 }
 
 func (man *QueryMan) CreateBulkWithStmt(stmtIdOrUserQuery string) (Bulk, error) {
+	return man.CreateBulkWithStmtContext(context.Background(), stmtIdOrUserQuery)
+}
+
+// CreateBulkWithStmtContext creates bulk in transaction of ctx if exists
+func (man *QueryMan) CreateBulkWithStmtContext(ctx context.Context, stmtIdOrUserQuery string) (Bulk, error) {
+	return man.CreateBulkWithOptions(ctx, stmtIdOrUserQuery, NewBulkOptions())
+}
+
+// CreateBulkWithOptions creates bulk which splits insert into chunks by opts
+func (man *QueryMan) CreateBulkWithOptions(ctx context.Context, stmtIdOrUserQuery string, opts BulkOptions) (Bulk, error) {
+	if tx := man.joinedTx(ctx); tx != nil {
+		return tx.CreateBulkWithOptions(ctx, stmtIdOrUserQuery, opts)
+	}
+
 	stmt, err := man.find(stmtIdOrUserQuery)
 	if err != nil {
 		return nil, err
@@ -201,8 +296,11 @@ func (man *QueryMan) CreateBulkWithStmt(stmtIdOrUserQuery string) (Bulk, error)
 	if stmt.eleType != eleTypeInsert && stmt.eleType != eleTypeUpdate {
 		return nil, ErrExecutionInvalidSqlType
 	}
+	if err = checkBulkStatement(stmt); err != nil {
+		return nil, err
+	}
 
-	bulk := newQuerymanBulk(man, stmt)
+	bulk := newQuerymanBulk(man.primary(ctx), stmt, opts)
 	return bulk, nil
 }
 
@@ -213,6 +311,15 @@ func (man *QueryMan) Execute(v ...interface{}) (sql.Result, error) {
 }
 
 func (man *QueryMan) ExecuteWithStmt(stmtIdOrUserQuery string, v ...interface{}) (sql.Result, error) {
+	return man.ExecuteWithStmtContext(context.Background(), stmtIdOrUserQuery, v...)
+}
+
+// ExecuteWithStmtContext executes statement in transaction of ctx if exists
+func (man *QueryMan) ExecuteWithStmtContext(ctx context.Context, stmtIdOrUserQuery string, v ...interface{}) (sql.Result, error) {
+	if tx := man.joinedTx(ctx); tx != nil {
+		return tx.ExecuteWithStmtContext(ctx, stmtIdOrUserQuery, v...)
+	}
+
 	stmt, err := man.find(stmtIdOrUserQuery)
 	if err != nil {
 		return nil, err
@@ -222,7 +329,7 @@ func (man *QueryMan) ExecuteWithStmt(stmtIdOrUserQuery string, v ...interface{})
 		return nil, ErrExecutionInvalidSqlType
 	}
 
-	return execute(man, stmt, v...)
+	return execute(man.primary(ctx), stmt, v...)
 }
 
 func (man *QueryMan) Query(v ...interface{}) *QueryResult {
@@ -232,6 +339,15 @@ func (man *QueryMan) Query(v ...interface{}) *QueryResult {
 }
 
 func (man *QueryMan) QueryWithStmt(stmtIdOrUserQuery string, v ...interface{}) *QueryResult {
+	return man.QueryWithStmtContext(context.Background(), stmtIdOrUserQuery, v...)
+}
+
+// QueryWithStmtContext queries in transaction of ctx if exists
+func (man *QueryMan) QueryWithStmtContext(ctx context.Context, stmtIdOrUserQuery string, v ...interface{}) *QueryResult {
+	if tx := man.joinedTx(ctx); tx != nil {
+		return tx.QueryWithStmtContext(ctx, stmtIdOrUserQuery, v...)
+	}
+
 	stmt, err := man.find(stmtIdOrUserQuery)
 	if err != nil {
 		return newQueryResultError(err)
@@ -241,8 +357,9 @@ func (man *QueryMan) QueryWithStmt(stmtIdOrUserQuery string, v ...interface{}) *
 		return newQueryResultError(ErrQueryInvalidSqlType)
 	}
 
-	queryedRow := queryMultiRow(man, stmt, v...)
+	queryedRow := man.queryRead(ctx, stmt, v...)
 	queryedRow.fieldNameConverter = man.fieldNameConverter
+	queryedRow.resultCheck.bind(stmt)
 	return queryedRow
 }
 
@@ -254,6 +371,15 @@ func (man *QueryMan) QueryRow(v ...interface{}) *QueryRowResult {
 
 
 func (man *QueryMan) QueryRowWithStmt(stmtIdOrUserQuery string, v ...interface{}) *QueryRowResult {
+	return man.QueryRowWithStmtContext(context.Background(), stmtIdOrUserQuery, v...)
+}
+
+// QueryRowWithStmtContext queries in transaction of ctx if exists
+func (man *QueryMan) QueryRowWithStmtContext(ctx context.Context, stmtIdOrUserQuery string, v ...interface{}) *QueryRowResult {
+	if tx := man.joinedTx(ctx); tx != nil {
+		return tx.QueryRowWithStmtContext(ctx, stmtIdOrUserQuery, v...)
+	}
+
 	stmt, err := man.find(stmtIdOrUserQuery)
 	if err != nil {
 		return newQueryRowResultError(err)
@@ -264,7 +390,7 @@ func (man *QueryMan) QueryRowWithStmt(stmtIdOrUserQuery string, v ...interface{}
 	}
 
 	var queryRowResult *QueryRowResult
-	queryResult := queryMultiRow(man, stmt, v...)
+	queryResult := man.queryRead(ctx, stmt, v...)
 	if queryResult.err != nil {
 		queryResult.Close()
 		queryRowResult = newQueryRowResultError(queryResult.err)
@@ -275,6 +401,7 @@ func (man *QueryMan) QueryRowWithStmt(stmtIdOrUserQuery string, v ...interface{}
 	queryResult.pstmt = nil
 	queryResult.rows = nil
 	queryRowResult.fieldNameConverter = man.fieldNameConverter
+	queryRowResult.resultCheck.bind(stmt)
 	return queryRowResult
 }
 
@@ -284,13 +411,19 @@ func (man *QueryMan) Begin() (*DBTransaction, error) {
 		return nil, err
 	}
 
-	runtime.SetFinalizer(tx, closeTransaction)
-	return newTransaction(man, tx, man, man.fieldNameConverter), nil
+	return man.newTransaction(tx), nil
+}
+
+func (man *QueryMan) newTransaction(tx *sql.Tx) *DBTransaction {
+	transaction := newTransaction(man, tx, man, man.fieldNameConverter, man.Dialect())
+	transaction.source = man.db
+	runtime.SetFinalizer(transaction, closeTransaction)
+	return transaction
 }
 
 // you have to commit before closing transaction
-func closeTransaction(tx *sql.Tx) {
-	tx.Rollback()
+func closeTransaction(t *DBTransaction) {
+	t.rollbackWith(ErrTransactionNotClosed)
 }
 
 func findFunctionName(pc uintptr) string {
@@ -1,3 +1,5 @@
+// +build mysql
+
 //
 // Licensed to the Apache Software Foundation (ASF) under one
 // or more contributor license agreements.  See the NOTICE file
@@ -26,7 +28,6 @@ package queryman
 import (
 	"bytes"
 	"database/sql"
-	"errors"
 	"flag"
 	"fmt"
 	"github.com/go-sql-driver/mysql"
@@ -61,52 +62,38 @@ const (
 	statusReady
 )
 
-var (
-	errNoMoreData = errors.New("no more data")
-)
-
 var querymanStatus uint8 = statusDisconnected
 
 var sourceName string
 var xmlFile string
 var queryManager *QueryMan
 
+var (
+	dbName = flag.String("db", "mmate", "database name")
+	userName = flag.String("user", "mmate", "Username")
+	password = flag.String("password", "angel", "passsword")
+	host = flag.String("host", "127.0.0.1:3306", "ip and port")
+)
 
-type City struct {
-	Id		int
-	Name	string
-	Age		int
-	IsMan	bool
-	Percentage float32
-	CreateTime time.Time
-	UpdateTime time.Time
+// go test -v -tags mysql -db=local -user=local -password=angel -host=127.0.0.1:3306
+func init() {
+	registerTestSuite(prepareMysqlSuite, func() {
+		os.Remove(xmlFile)
+	})
 }
 
-
-// go test -v -db=local -user=local -password=angel -host=127.0.0.1:3306
-func TestMain(m *testing.M) {
+func prepareMysqlSuite() error {
 	prepareSourceName()
 
 	var err error
 	xmlFile, err = prepareXmlFile()
 	if err != nil {
-		fmt.Printf("fail to prepare sample xml file : %s", err.Error())
-		return
+		return fmt.Errorf("fail to prepare sample xml file : %s", err.Error())
 	}
-
-	code := m.Run()
-	os.Remove(xmlFile)
-	os.Exit(code)
+	return nil
 }
 
 func prepareSourceName() {
-	dbName := flag.String("db", "mmate", "database name")
-	userName := flag.String("user", "mmate", "Username")
-	password := flag.String("password", "angel", "passsword")
-	host := flag.String("host", "127.0.0.1:3306", "ip and port")
-
-	flag.Parse()
-
 	sourceName = fmt.Sprintf("%s:%s@tcp(%s)/%s?autocommit=true&timeout=10s&readTimeout=10s&loc=Asia%%2Fseoul&writeTimeout=1s&parseTime=true&charset=utf8mb4,utf8",
 		*userName, *password, *host, *dbName)
 }
@@ -1220,17 +1207,6 @@ func TestSelectNoRows(t *testing.T) {
 }
 
 
-func createCity() City {
-	city := City{}
-	city.Name = "jin.freestyle@gmail.com"
-	city.Age = 142
-	city.IsMan = true
-	city.Percentage = 43.4
-	city.CreateTime = time.Now()
-	city.UpdateTime = time.Now()
-	return city
-}
-
 func TestUpsertAlbum(t *testing.T)	{
 	setup()
 
@@ -1272,12 +1248,6 @@ func upsertAlbum(list []AlbumData) (int, error)	{
 	return int(affected), err
 }
 
-type AlbumData struct {
-	Id 	int
-	Score int
-}
-
-
 func TestBatchInsert(t *testing.T)	{
 	setup()
 
@@ -0,0 +1,1936 @@
+//
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+//
+// @project queryman
+// @author agent
+// @date 2026. 10. 18. PM 11:44
+//
+
+package queryman
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lib/pq"
+	_ "github.com/mattn/go-sqlite3"
+)
+
+const (
+	liteXmlFilePrefix = "litequery."
+)
+
+var liteTempDir string
+var liteQueryManager *QueryMan
+
+var liteXmlSample = []byte(`
+<?xml version="1.0" encoding="UTF-8" ?>
+<query>
+    <update id="DropCityTable">
+        drop table if exists city
+    </update>
+    <update id="CreateCityTable">
+create table city (
+    id  integer primary key autoincrement,
+    name varchar(64) default null,
+    age  int  default 0,
+    is_man  boolean default true,
+    percentage real default 0.0,
+    create_time datetime default CURRENT_TIMESTAMP,
+    update_time datetime
+)
+    </update>
+	<update id="DropAlbumTable">
+        drop table if exists album
+    </update>
+    <update id="CreateAlbumTable">
+	create table album (
+    	id  int,
+    	score int,
+    	primary key (id)
+	)
+    </update>
+	<select id="liteSelectAlbumCount">
+		SELECT COUNT(*) FROM album
+	</select>
+	<select id="SelectAlbumScore">
+		SELECT score FROM album WHERE id={Id}
+	</select>
+	<insert id="liteInsertAlbum">
+		INSERT INTO album  ( id, score ) VALUES ({Id},{Score})
+	</insert>
+	<update id="liteUpdateAlbumScore">
+		UPDATE album SET score={Score} WHERE id={Id}
+	</update>
+	<update id="liteUpdateAlbumScoreWithIf">
+		UPDATE album SET score={Score} WHERE id={Id}
+		<if key="Score">
+		AND score >= 0
+		</if>
+	</update>
+	<delete id="liteDeleteAlbum">
+		DELETE FROM album WHERE id={Id}
+	</delete>
+	<insert id="liteUpsertAlbum">
+		INSERT INTO album  ( id, score
+        )
+        VALUES
+        (
+            {Id},
+            {Score}
+        )
+        ON CONFLICT(id) DO
+        UPDATE SET
+            score = score + excluded.score
+	</insert>
+	<upsert id="liteUpsertAlbumScore" conflictKey="id">
+		INSERT INTO album (id, score) VALUES ({Id}, {Score})
+	</upsert>
+	<upsert id="liteUpsertAlbumScoreWithKey" conflictKey="id" keyProperty="Id">
+		INSERT INTO album (id, score) VALUES ({Id}, {Score})
+	</upsert>
+	<insert id="liteInsertOrIgnoreAlbumWithKey" keyProperty="Id">
+		INSERT OR IGNORE INTO album (id, score) VALUES ({Id}, {Score})
+	</insert>
+    <insert id="InsertCity">
+        INSERT INTO CITY(NAME,AGE,IS_MAN,PERCENTAGE,CREATE_TIME,UPDATE_TIME) VALUES({Name},{Age},{IsMan},{Percentage},{CreateTime},{UpdateTime})
+    </insert>
+    <insert id="liteInsertCityWithKey" keyProperty="Id">
+        INSERT INTO CITY(NAME,AGE) VALUES({Name},{Age})
+    </insert>
+    <update id="UpdateCityWithName">
+        UPDATE CITY SET AGE={Age} WHERE NAME={Name}
+    </update>
+    <select id="SelectCityWithName">
+        SELECT * FROM CITY WHERE NAME like {Name}
+    </select>
+    <select id="CountCity">
+        SELECT Count(*) FROM CITY
+    </select>
+    <select id="SelectCityTimeText">
+        SELECT name, strftime('%Y-%m-%d %H:%M:%S', create_time) AS create_time FROM CITY WHERE NAME={Name}
+    </select>
+	<select id="SelectCityWithIf">
+        SELECT id, name, age
+        FROM city
+        WHERE is_man={IsMan}
+        <if key="Name">
+        AND name={Name}
+        </if>
+        <if key="Age">
+        AND age={Age}
+        </if>
+    </select>
+</query>
+`)
+
+func init() {
+	registerTestSuite(prepareSqliteSuite, func() {
+		if liteQueryManager != nil {
+			liteQueryManager.Close()
+		}
+		os.RemoveAll(liteTempDir)
+	})
+}
+
+func prepareSqliteSuite() error {
+	var err error
+	liteTempDir, err = ioutil.TempDir("", "queryman")
+	if err != nil {
+		return fmt.Errorf("fail to create temp dir : %s", err.Error())
+	}
+
+	err = ioutil.WriteFile(filepath.Join(liteTempDir, liteXmlFilePrefix+"xml"), liteXmlSample, 0644)
+	if err != nil {
+		return fmt.Errorf("fail to prepare sample xml file : %s", err.Error())
+	}
+
+	sourceName := fmt.Sprintf("file:%s?_busy_timeout=5000&_loc=auto", filepath.Join(liteTempDir, "queryman.db"))
+	pref := NewQuerymanPreference(liteTempDir, sourceName)
+	pref.DriverName = "sqlite3"
+	pref.Fileset = liteXmlFilePrefix + "xml"
+	liteQueryManager, err = NewQueryman(pref)
+	if err != nil {
+		return fmt.Errorf("fail to create queryman : %s", err.Error())
+	}
+	return nil
+}
+
+func liteSetup(t *testing.T) {
+	for _, id := range []string{"DropCityTable", "CreateCityTable", "DropAlbumTable", "CreateAlbumTable"} {
+		if _, err := liteQueryManager.ExecuteWithStmt(id); err != nil {
+			t.Fatalf("fail to execute(%s) : %s", id, err.Error())
+		}
+	}
+}
+
+type liteNullableCity struct {
+	Id		sql.NullInt64
+	Name	sql.NullString
+	Age		sql.NullInt64
+	IsMan	sql.NullBool
+	Percentage sql.NullFloat64
+	CreateTime sql.NullTime
+	UpdateTime sql.NullTime
+}
+
+func checkLiteInsertId(t *testing.T, result sql.Result, err error, expected int64) {
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	if id != expected {
+		t.Fatalf("invalid last insert id : %d", id)
+	}
+}
+
+func checkLiteMultiResult(t *testing.T, result sql.Result, err error, insertingCount int) {
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	if int(affected) != insertingCount {
+		t.Fatalf("invalid affected count : %d", affected)
+	}
+
+	pstmtResult, ok := result.(ExecMultiResult)
+	if !ok {
+		t.Fatalf("result type is not ExecMultiResult")
+	}
+	if len(pstmtResult.GetInsertIdList()) != insertingCount {
+		t.Fatalf("inserted id count is not valid. %d", len(pstmtResult.GetInsertIdList()))
+	}
+	for i, id := range pstmtResult.GetInsertIdList() {
+		if id != int64(i+1) {
+			t.Fatalf("invalid inserted id list : %v", pstmtResult.GetInsertIdList())
+		}
+	}
+}
+
+func TestSqliteQueryUnknownStatementId(t *testing.T) {
+	_, err := liteQueryManager.Execute("UnknownSomethingStatement")
+	if err == nil {
+		t.Error("queryManager report statement found")
+	}
+}
+
+func TestSqliteInsertBareParams(t *testing.T) {
+	liteSetup(t)
+
+	result, err := liteQueryManager.ExecuteWithStmt("InsertCity", "bare param", 42, true, 40.0, time.Now(), nil)
+	checkLiteInsertId(t, result, err, 1)
+}
+
+func TestSqliteInsertSlice(t *testing.T) {
+	liteSetup(t)
+
+	args := []interface{}{"slice name", 42, true, 40.0, time.Now(), nil}
+	result, err := liteQueryManager.ExecuteWithStmt("InsertCity", args)
+	checkLiteInsertId(t, result, err, 1)
+
+	result, err = liteQueryManager.ExecuteWithStmt("InsertCity", &args)
+	checkLiteInsertId(t, result, err, 2)
+}
+
+func TestSqliteInsertObject(t *testing.T) {
+	liteSetup(t)
+
+	city := createCity()
+	result, err := liteQueryManager.ExecuteWithStmt("InsertCity", city)
+	checkLiteInsertId(t, result, err, 1)
+
+	city.Name = "ptr test"
+	result, err = liteQueryManager.ExecuteWithStmt("InsertCity", &city)
+	checkLiteInsertId(t, result, err, 2)
+}
+
+func TestSqliteInsertMap(t *testing.T) {
+	liteSetup(t)
+
+	args := make(map[string]interface{})
+	args["Name"] = "map name"
+	args["Age"] = nil
+	args["IsMan"] = true
+	args["Percentage"] = 19.21
+	args["CreateTime"] = time.Now()
+	args["UpdateTime"] = time.Now()
+
+	result, err := liteQueryManager.ExecuteWithStmt("InsertCity", args)
+	checkLiteInsertId(t, result, err, 1)
+}
+
+func TestSqliteInsertNullableSlice(t *testing.T) {
+	liteSetup(t)
+
+	args := []interface{}{sql.NullString{String:"test_city"}, sql.NullInt64{}, sql.NullBool{}, sql.NullFloat64{}, time.Now(), nil}
+	result, err := liteQueryManager.ExecuteWithStmt("InsertCity", args)
+	checkLiteInsertId(t, result, err, 1)
+}
+
+func TestSqliteInsertNestedSlice(t *testing.T) {
+	liteSetup(t)
+
+	params := make([][]interface{}, 0)
+	for i:=0; i<5; i++ {
+		params = append(params, []interface{}{"slice name", 42, true, 40.0, time.Now(), nil})
+	}
+
+	result, err := liteQueryManager.ExecuteWithStmt("InsertCity", params)
+	checkLiteMultiResult(t, result, err, 5)
+}
+
+func TestSqliteInsertNestedMap(t *testing.T) {
+	liteSetup(t)
+
+	params := make([]map[string]interface{}, 0)
+	for i:=0; i<5; i++ {
+		args := make(map[string]interface{})
+		args["Name"] = "nested map"
+		args["Age"] = nil
+		args["IsMan"] = true
+		args["Percentage"] = 19.21
+		args["CreateTime"] = time.Now()
+		args["UpdateTime"] = time.Now()
+		params = append(params, args)
+	}
+
+	result, err := liteQueryManager.ExecuteWithStmt("InsertCity", params)
+	checkLiteMultiResult(t, result, err, 5)
+}
+
+func TestSqliteInsertNestedObject(t *testing.T) {
+	liteSetup(t)
+
+	params := make([]interface{}, 0)
+	for i:=0; i<5; i++ {
+		params = append(params, createCity())
+	}
+	result, err := liteQueryManager.ExecuteWithStmt("InsertCity", params)
+	checkLiteMultiResult(t, result, err, 5)
+
+	liteSetup(t)
+	params = make([]interface{}, 0)
+	for i:=0; i<5; i++ {
+		city := createCity()
+		params = append(params, &city)
+	}
+	result, err = liteQueryManager.ExecuteWithStmt("InsertCity", params)
+	checkLiteMultiResult(t, result, err, 5)
+}
+
+func TestSqliteTransactionInsert(t *testing.T) {
+	liteSetup(t)
+
+	tx, err := liteQueryManager.Begin()
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecuteWithStmt("InsertCity", createCity())
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	affected, _ := result.RowsAffected()
+	id, _ := result.LastInsertId()
+	if id != 1 || affected != 1 {
+		t.Fatalf("invalid result : id=%d, affected=%d", id, affected)
+	}
+}
+
+func TestSqliteQueryButNoMoreData(t *testing.T) {
+	liteSetup(t)
+
+	result := liteQueryManager.QueryWithStmt("SelectCityWithName", "slice name")
+	if result.GetError() != nil {
+		t.Fatalf(result.GetError().Error())
+	}
+	defer result.Close()
+
+	if result.Next() {
+		t.Error("should be no more data")
+	}
+}
+
+func TestSqliteQueryInClauseDelete(t *testing.T) {
+	liteSetup(t)
+
+	liteQueryManager.ExecuteWithStmt("InsertCity", "seoul", 42, true, 40.0, time.Now(), nil)
+	liteQueryManager.ExecuteWithStmt("InsertCity", "pusan", 43, true, 40.0, time.Now(), nil)
+	liteQueryManager.ExecuteWithStmt("InsertCity", "sejong", 44, true, 40.0, time.Now(), nil)
+
+	sqlStr := "DELETE FROM CITY WHERE NAME IN ( {Names} )"
+	result, err := liteQueryManager.ExecuteWithStmt(sqlStr, []string{"seoul", "pusan"})
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	affected, _ := result.RowsAffected()
+	if affected != 2 {
+		t.Fatalf("row affected = %d", affected)
+	}
+}
+
+func TestSqliteQueryInClause(t *testing.T) {
+	liteSetup(t)
+
+	liteQueryManager.ExecuteWithStmt("InsertCity", "seoul", 42, true, 40.0, time.Now(), nil)
+	liteQueryManager.ExecuteWithStmt("InsertCity", "pusan", 43, true, 40.0, time.Now(), nil)
+	liteQueryManager.ExecuteWithStmt("InsertCity", "sejong", 44, true, 40.0, time.Now(), nil)
+
+	names := []string{"seoul", "pusan"}
+	m := map[string]interface{}{"Names": names, "Age": 10}
+	queries := []func() *QueryResult{
+		func() *QueryResult {
+			return liteQueryManager.QueryWithStmt("SELECT * FROM CITY WHERE Age > {Age} AND NAME IN ( {Names} )", 10, names)
+		},
+		func() *QueryResult {
+			return liteQueryManager.QueryWithStmt("SELECT * FROM CITY WHERE NAME IN ({Names}) AND Age > {Age}", names, 10)
+		},
+		func() *QueryResult {
+			return liteQueryManager.QueryWithStmt("SELECT * FROM CITY WHERE NAME IN ({Names}) AND Age > {Age}", m)
+		},
+	}
+
+	for i, query := range queries {
+		result := query()
+		if result.GetError() != nil {
+			t.Fatalf("[%d] %s", i, result.GetError().Error())
+		}
+
+		list := make([]liteNullableCity, 0)
+		for result.Next() {
+			city := liteNullableCity{}
+			err := result.Scan(&city)
+			if err != nil {
+				t.Fatalf("[%d] %s", i, err.Error())
+			}
+			list = append(list, city)
+		}
+		result.Close()
+
+		if len(list) != 2 || list[0].Name.String != "seoul" || !list[0].CreateTime.Valid {
+			t.Fatalf("[%d] invalid selection : %v", i, list)
+		}
+	}
+}
+
+func TestSqliteQueryOneObject(t *testing.T) {
+	liteSetup(t)
+
+	_, err := liteQueryManager.ExecuteWithStmt("InsertCity", "bare param", 42, true, 40.0, time.Now(), nil)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	city := &City{}
+	result := liteQueryManager.QueryWithStmt("SelectCityWithName", "bare param")
+	if result.GetError() != nil {
+		t.Fatalf(result.GetError().Error())
+	}
+	defer result.Close()
+
+	if !result.Next() {
+		t.Fatalf(errNoMoreData.Error())
+	}
+
+	err = result.Scan(city)
+	if err != nil {
+		t.Fatalf("fail to scan : %s", err.Error())
+	}
+	if city.Id != 1 || city.Age != 42 || !city.IsMan || city.Percentage != 40.0 || city.CreateTime.IsZero() {
+		t.Fatalf("invalid selection : %v", city)
+	}
+}
+
+func TestSqliteQueryRowBare(t *testing.T) {
+	liteSetup(t)
+
+	_, err := liteQueryManager.ExecuteWithStmt("InsertCity", "sample_city", 42, true, 40.0, time.Now(), time.Now())
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	count := 0
+	err = liteQueryManager.QueryRowWithStmt("CountCity").Scan(&count)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	if count != 1 {
+		t.Fatalf("invalid city count %d", count)
+	}
+}
+
+func TestSqliteQueryRowStruct(t *testing.T) {
+	liteSetup(t)
+
+	_, err := liteQueryManager.ExecuteWithStmt("InsertCity", "unexported_field", 42, true, 40.0, time.Now(), time.Now())
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	city := liteNullableCity{}
+	err = liteQueryManager.QueryRowWithStmt("SelectCityWithName", "unexported_field").Scan(&city)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	if city.Age.Int64 != 42 {
+		t.Fatalf("selecting mismatch")
+	}
+}
+
+func TestSqliteQueryBare(t *testing.T) {
+	liteSetup(t)
+
+	_, err := liteQueryManager.ExecuteWithStmt("InsertCity", "unexported_field", 42, true, 40.0, time.Now(), time.Now())
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	type HasUnexportedFieldCity struct {
+		Name	string
+		help	string
+	}
+
+	sample := HasUnexportedFieldCity{Name:"unexported_field"}
+	m := map[string]string{"Name": "unexported_field"}
+	for _, param := range []interface{}{sample, m} {
+		result := liteQueryManager.QueryWithStmt("SelectCityWithName", param)
+		if result.GetError() != nil {
+			t.Fatalf(result.GetError().Error())
+		}
+
+		var id int
+		var name string
+		var age int
+		var isMan bool
+		var percentage float32
+		var createTime time.Time
+		var updateTime time.Time
+
+		if !result.Next() {
+			t.Fatalf(errNoMoreData.Error())
+		}
+		err = result.Scan(&id, &name, &age, &isMan, &percentage, &createTime, &updateTime)
+		result.Close()
+		if err != nil {
+			t.Fatalf(err.Error())
+		}
+	}
+}
+
+func TestSqliteQueryNull(t *testing.T) {
+	liteSetup(t)
+
+	_, err := liteQueryManager.ExecuteWithStmt("InsertCity", "nullable", nil, nil, nil, nil, nil)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	city := City{}
+	err = liteQueryManager.QueryRowWithStmt("SelectCityWithName", "nullable").Scan(&city)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	nullable := liteNullableCity{}
+	err = liteQueryManager.QueryRowWithStmt("SelectCityWithName", "%null%").Scan(&nullable)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	if nullable.Age.Valid || nullable.CreateTime.Valid {
+		t.Fatalf("null is expected : %v", nullable)
+	}
+}
+
+func TestSqliteQueryTimeText(t *testing.T) {
+	liteSetup(t)
+
+	created := time.Date(2020, 4, 13, 10, 20, 30, 0, time.Local)
+	_, err := liteQueryManager.ExecuteWithStmt("InsertCity", "time", 1, true, 1.0, created, nil)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	type CityTime struct {
+		Name		string
+		CreateTime	time.Time
+	}
+
+	city := CityTime{}
+	err = liteQueryManager.QueryRowWithStmt("SelectCityTimeText", "time").Scan(&city)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	if city.CreateTime.Format("2006-01-02 15:04:05") != "2020-04-13 10:20:30" {
+		t.Fatalf("invalid time : %v", city.CreateTime)
+	}
+}
+
+func TestSqliteSelectCityWithIf(t *testing.T) {
+	liteSetup(t)
+
+	_, err := liteQueryManager.ExecuteWithStmt("InsertCity", "map_name", 42, true, 40.0, time.Now(), time.Now())
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	city := liteNullableCity{}
+	m := make(map[string]interface{})
+	m["IsMan"] = true
+
+	m["Name"] = "map_name_not_found"
+	err = liteQueryManager.QueryRowWithStmt("SelectCityWithIf", m).Scan(&city)
+	if err != ErrNoRows {
+		t.Fatalf("should be no rows")
+	}
+
+	m["Name"] = "map_name"
+	err = liteQueryManager.QueryRowWithStmt("SelectCityWithIf", m).Scan(&city)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	if !(city.Age.Valid && city.Age.Int64 == 42) {
+		t.Fatalf("invalid age")
+	}
+
+	m["Age"] = 42
+	err = liteQueryManager.QueryRowWithStmt("SelectCityWithIf", m).Scan(&city)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	if !(city.Age.Valid && city.Age.Int64 == 42) {
+		t.Fatalf("invalid age")
+	}
+}
+
+func TestSqliteUpsertAlbum(t *testing.T) {
+	liteSetup(t)
+
+	list := make([]AlbumData, 0)
+	list = append(list, AlbumData{Id:100, Score:10})
+	list = append(list, AlbumData{Id:200, Score:31})
+	list = append(list, AlbumData{Id:300, Score:9})
+	list = append(list, AlbumData{Id:400, Score:8})
+	list = append(list, AlbumData{Id:500, Score:7})
+	list = append(list, AlbumData{Id:100, Score:12})
+
+	affected, err := liteUpsertAlbum(list)
+	if err != nil {
+		t.Fatalf("fail to UpsertAlbum : %s", err.Error())
+	}
+	// sqlite counts updated row as 1 change
+	if affected != 6 {
+		t.Fatalf("with %d, but %d", 6, affected)
+	}
+
+	score := 0
+	err = liteQueryManager.QueryRowWithStmt("SelectAlbumScore", 100).Scan(&score)
+	if err != nil || score != 22 {
+		t.Fatalf("invalid score : %d, %v", score, err)
+	}
+}
+
+func TestSqliteBatchInsert(t *testing.T) {
+	liteSetup(t)
+
+	list := make([]AlbumData, 0)
+	list = append(list, AlbumData{Id:100, Score:110})
+	list = append(list, AlbumData{Id:200, Score:131})
+	list = append(list, AlbumData{Id:300, Score:19})
+	list = append(list, AlbumData{Id:400, Score:18})
+	list = append(list, AlbumData{Id:500, Score:17})
+
+	affected, err := liteInsertAlbum(list)
+	if err != nil {
+		t.Fatalf("fail to insertAlbum : %s", err.Error())
+	}
+	if affected != 5 {
+		t.Fatalf("with %d, but %d", 5, affected)
+	}
+
+	if count := liteSelectAlbumCount(); count != 5 {
+		t.Fatalf("with %d, but %d", 5, count)
+	}
+
+	bulk, err := liteQueryManager.CreateBulkWithStmt("liteInsertAlbum")
+	if err != nil {
+		t.Fatalf("fail to insertAlbum : %s", err.Error())
+	}
+	bulk.AddBatch(&AlbumData{Id:1100, Score:110})
+	bulk.AddBatch(&AlbumData{Id:1200, Score:131})
+	result, err := bulk.Execute()
+	if err != nil {
+		t.Fatalf("fail to insertAlbum : %s", err.Error())
+	}
+	if a, _ := result.RowsAffected(); a != 2 {
+		t.Fatalf("with %d, but %d", 2, a)
+	}
+
+	if count := liteSelectAlbumCount(); count != 7 {
+		t.Fatalf("with %d, but %d", 7, count)
+	}
+}
+
+func TestSqliteBatchInsertWithMap(t *testing.T) {
+	liteSetup(t)
+
+	bulk, err := liteQueryManager.CreateBulkWithStmt("liteInsertAlbum")
+	if err != nil {
+		t.Fatalf("fail to insertAlbum : %s", err.Error())
+	}
+	for i:= 0; i<=10; i++	{
+		m := make(map[string]interface{})
+		m["Id"] = i+100
+		m["Score"] = i+100+5
+		bulk.AddBatch(m)
+	}
+
+	result, err := bulk.Execute()
+	if err != nil {
+		t.Fatalf("fail to TestBatchInsertWithMap : %s", err.Error())
+	}
+	if r, _ := result.RowsAffected(); r != 11 {
+		t.Fatalf("with %d, but %d", 11, r)
+	}
+
+	if count := liteSelectAlbumCount(); count != 11 {
+		t.Fatalf("with %d, but %d", 11, count)
+	}
+}
+
+func TestSqliteBatchInsertId(t *testing.T) {
+	liteSetup(t)
+
+	_, err := liteQueryManager.ExecuteWithStmt("InsertCity", createCity())
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	bulk, err := liteQueryManager.CreateBulkWithStmt("InsertCity")
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	for i:=0; i<3; i++ {
+		bulk.AddBatch(createCity())
+	}
+
+	result, err := bulk.Execute()
+	checkLiteInsertId(t, result, err, 2)
+}
+
+func TestSqliteBulkInsertIdList(t *testing.T) {
+	liteSetup(t)
+
+	opts := NewBulkOptions()
+	opts.MaxPlaceholders = 4
+	bulk, err := liteQueryManager.CreateBulkWithOptions(context.Background(), "liteInsertCityWithKey", opts)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	cities := make([]*City, 0)
+	for i := 0; i < 3; i++ {
+		city := createCity()
+		cities = append(cities, &city)
+	}
+	if err = bulk.AddBatch(cities[0]); err != nil {
+		t.Fatalf(err.Error())
+	}
+	if err = bulk.AddBatch([]*City{cities[1], cities[2]}); err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	result, err := bulk.Execute()
+	checkLiteMultiResult(t, result, err, 3)
+	if chunks := result.(ExecMultiResult).GetChunkCount(); chunks != 2 {
+		t.Fatalf("invalid chunk count : %d", chunks)
+	}
+	for i, city := range cities {
+		if city.Id != i+1 {
+			t.Fatalf("generated id should be written to keyProperty : %d, %d", i, city.Id)
+		}
+	}
+}
+
+func liteUpsertAlbum(list []AlbumData) (int, error)	{
+	b, err := liteQueryManager.CreateBulk()
+	if err != nil {
+		return 0, err
+	}
+
+	err = b.AddBatch(list)
+	if err != nil {
+		return 0, err
+	}
+
+	result, err := b.Execute()
+	if err != nil {
+		return 0, err
+	}
+
+	affected, err := result.RowsAffected()
+	return int(affected), err
+}
+
+func liteInsertAlbum(list []AlbumData) (int, error)	{
+	b, err := liteQueryManager.CreateBulk()
+	if err != nil {
+		return 0, err
+	}
+
+	err = b.AddBatch(list)
+	if err != nil {
+		return 0, err
+	}
+
+	result, err := b.Execute()
+	if err != nil {
+		return 0, err
+	}
+
+	affected, err := result.RowsAffected()
+	return int(affected), err
+}
+
+func liteSelectAlbumCount()	int		{
+	count := 0
+	err := liteQueryManager.QueryRow().Scan(&count)
+	if err != nil {
+		return 0
+	}
+	return count
+}
+
+func openLiteReplicaSet(t *testing.T) *QueryMan {
+	primaryName := fmt.Sprintf("file:%s?_busy_timeout=5000", filepath.Join(liteTempDir, "primary.db"))
+	replicaName := fmt.Sprintf("file:%s?_busy_timeout=5000", filepath.Join(liteTempDir, "replica.db"))
+
+	pref := NewQuerymanPreference(liteTempDir, primaryName)
+	pref.DriverName = "sqlite3"
+	pref.Fileset = liteXmlFilePrefix + "xml"
+	pref.AddReplica(fmt.Sprintf("file:%s?mode=ro", filepath.Join(liteTempDir, "nowhere", "replica.db")))
+	pref.AddReplica(replicaName)
+
+	replica, err := sql.Open("sqlite3", replicaName)
+	if err != nil {
+		t.Fatalf("fail to open replica : %s", err.Error())
+	}
+	defer replica.Close()
+	for _, query := range []string{"drop table if exists album", "create table album (id int, score int, primary key (id))",
+		"insert into album (id, score) values (1, 200)"} {
+		if _, err = replica.Exec(query); err != nil {
+			t.Fatalf("fail to prepare replica : %s", err.Error())
+		}
+	}
+
+	man, err := NewQueryman(pref)
+	if err != nil {
+		t.Fatalf("fail to create queryman : %s", err.Error())
+	}
+	return man
+}
+
+func TestSqliteUseMasterKeepsParent(t *testing.T) {
+	view := openLiteReplicaSet(t).UseMaster()
+	defer view.Close()
+
+	// parent is not referenced except by the view. its finalizer should not close connections
+	for i := 0; i < 5; i++ {
+		runtime.GC()
+		time.Sleep(time.Millisecond * 10)
+	}
+	if _, err := view.ExecuteWithStmt("DropAlbumTable"); err != nil {
+		t.Fatalf("connection of view should be alive : %s", err.Error())
+	}
+}
+
+func TestSqliteReplicaRouting(t *testing.T) {
+	man := openLiteReplicaSet(t)
+	defer man.Close()
+
+	for _, id := range []string{"DropAlbumTable", "CreateAlbumTable"} {
+		if _, err := man.ExecuteWithStmt(id); err != nil {
+			t.Fatalf("fail to execute(%s) : %s", id, err.Error())
+		}
+	}
+	if _, err := man.ExecuteWithStmt("liteInsertAlbum", 1, 100); err != nil {
+		t.Fatalf("fail to insert : %s", err.Error())
+	}
+
+	// unreachable replica is ejected, so every select goes to healthy replica
+	for i := 0; i < 3; i++ {
+		score := 0
+		if err := man.QueryRowWithStmt("SelectAlbumScore", 1).Scan(&score); err != nil {
+			t.Fatalf("fail to select : %s", err.Error())
+		}
+		if score != 200 {
+			t.Fatalf("select should be routed to replica : %d", score)
+		}
+	}
+
+	score := 0
+	if err := man.UseMaster().QueryRowWithStmt("SelectAlbumScore", 1).Scan(&score); err != nil {
+		t.Fatalf("fail to select : %s", err.Error())
+	}
+	if score != 100 {
+		t.Fatalf("select should be routed to primary : %d", score)
+	}
+
+	tx, err := man.Begin()
+	if err != nil {
+		t.Fatalf("fail to begin : %s", err.Error())
+	}
+	defer tx.Rollback()
+	if err = tx.QueryRowWithStmt("SelectAlbumScore", 1).Scan(&score); err != nil {
+		t.Fatalf("fail to select : %s", err.Error())
+	}
+	if score != 100 {
+		t.Fatalf("transaction should use primary : %d", score)
+	}
+}
+
+func TestSqliteShardedQueryman(t *testing.T) {
+	prefs := make([]QuerymanPreference, 0)
+	for i := 0; i < 2; i++ {
+		sourceName := fmt.Sprintf("file:%s?_busy_timeout=5000", filepath.Join(liteTempDir, fmt.Sprintf("shard%d.db", i)))
+		pref := NewQuerymanPreference(liteTempDir, sourceName)
+		pref.DriverName = "sqlite3"
+		pref.Fileset = liteXmlFilePrefix + "xml"
+		prefs = append(prefs, pref)
+	}
+
+	sharded, err := NewShardedQueryman(prefs, ShardByKey("Id"))
+	if err != nil {
+		t.Fatalf("fail to create sharded queryman : %s", err.Error())
+	}
+	defer sharded.Close()
+
+	for i := 0; i < sharded.ShardCount(); i++ {
+		for _, id := range []string{"DropAlbumTable", "CreateAlbumTable"} {
+			if _, err = sharded.Shard(i).ExecuteWithStmt(id); err != nil {
+				t.Fatalf("fail to execute(%s) : %s", id, err.Error())
+			}
+		}
+	}
+
+	for i := 1; i <= 5; i++ {
+		if _, err = sharded.ExecuteWithStmt("liteInsertAlbum", AlbumData{Id: i, Score: i * 10}); err != nil {
+			t.Fatalf("fail to insert : %s", err.Error())
+		}
+	}
+
+	count := 0
+	if err = sharded.Shard(1).QueryRowWithStmt("liteSelectAlbumCount").Scan(&count); err != nil {
+		t.Fatalf("fail to count : %s", err.Error())
+	}
+	if count != 3 {
+		t.Fatalf("odd ids should be in shard 1 : %d", count)
+	}
+
+	score := 0
+	if err = sharded.QueryRowWithStmt("SelectAlbumScore", map[string]interface{}{"Id": 4}).Scan(&score); err != nil {
+		t.Fatalf("fail to select : %s", err.Error())
+	}
+	if score != 40 {
+		t.Fatalf("invalid score : %d", score)
+	}
+
+	result := sharded.QueryAllShards("SELECT id, score FROM album ORDER BY id")
+	if result.GetError() != nil {
+		t.Fatalf("fail to query all shards : %s", result.GetError().Error())
+	}
+	defer result.Close()
+
+	rows := make(map[int]int)
+	for result.Next() {
+		album := AlbumData{}
+		if err = result.Scan(&album); err != nil {
+			t.Fatalf("fail to scan : %s", err.Error())
+		}
+		if album.Id % 2 != result.Shard() {
+			t.Fatalf("album %d is from invalid shard %d", album.Id, result.Shard())
+		}
+		rows[album.Id] = album.Score
+	}
+	if len(rows) != 5 {
+		t.Fatalf("invalid merged rows : %v", rows)
+	}
+
+	if _, err = sharded.ExecuteWithStmt("liteInsertAlbum"); err == nil {
+		t.Fatalf("statement without shard key should fail")
+	}
+}
+
+func TestSqliteNestedTransaction(t *testing.T) {
+	liteSetup(t)
+
+	tx, err := liteQueryManager.Begin()
+	if err != nil {
+		t.Fatalf("fail to begin : %s", err.Error())
+	}
+	defer tx.Rollback()
+
+	insert := func(executor StatementExecutor, id int) {
+		if _, err := executor.ExecuteWithStmt("liteInsertAlbum", id, id * 10); err != nil {
+			t.Fatalf("fail to insert %d : %s", id, err.Error())
+		}
+	}
+	insert(tx, 1)
+
+	nested, err := tx.Begin()
+	if err != nil {
+		t.Fatalf("fail to begin nested : %s", err.Error())
+	}
+	insert(nested, 2)
+	if err = nested.Rollback(); err != nil {
+		t.Fatalf("fail to rollback nested : %s", err.Error())
+	}
+	if err = nested.Commit(); err != sql.ErrTxDone {
+		t.Fatalf("finished nested transaction should report done : %v", err)
+	}
+
+	nested, err = tx.Begin()
+	if err != nil {
+		t.Fatalf("fail to begin nested : %s", err.Error())
+	}
+	insert(nested, 3)
+	inner, err := nested.Begin()
+	if err != nil {
+		t.Fatalf("fail to begin inner : %s", err.Error())
+	}
+	insert(inner, 4)
+	if err = inner.Rollback(); err != nil {
+		t.Fatalf("fail to rollback inner : %s", err.Error())
+	}
+	if err = nested.Commit(); err != nil {
+		t.Fatalf("fail to commit nested : %s", err.Error())
+	}
+
+	if err = tx.Savepoint("manual"); err != nil {
+		t.Fatalf("fail to create savepoint : %s", err.Error())
+	}
+	insert(tx, 5)
+	if err = tx.RollbackTo("manual"); err != nil {
+		t.Fatalf("fail to rollback to savepoint : %s", err.Error())
+	}
+	if err = tx.Release("manual"); err != nil {
+		t.Fatalf("fail to release savepoint : %s", err.Error())
+	}
+
+	if err = tx.Commit(); err != nil {
+		t.Fatalf("fail to commit : %s", err.Error())
+	}
+
+	if count := liteSelectAlbumCount(); count != 2 {
+		t.Fatalf("only album 1 and 3 should be committed : %d", count)
+	}
+}
+
+func TestSqliteRunInTransaction(t *testing.T) {
+	liteSetup(t)
+
+	opts := NewTxOptions()
+	opts.RetryBackoff = time.Millisecond
+	attempt := 0
+	err := liteQueryManager.RunInTransaction(context.Background(), opts, func(tx *DBTransaction) error {
+		attempt++
+		if _, err := tx.ExecuteWithStmt("liteInsertAlbum", attempt, 10); err != nil {
+			return err
+		}
+		if attempt < 3 {
+			return &pq.Error{Code: "40001"}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("fail to run transaction : %s", err.Error())
+	}
+	if attempt != 3 {
+		t.Fatalf("transaction should be retried : %d", attempt)
+	}
+	if count := liteSelectAlbumCount(); count != 1 {
+		t.Fatalf("failed attempts should be rolled back : %d", count)
+	}
+
+	attempt = 0
+	err = liteQueryManager.RunInTransaction(context.Background(), opts, func(tx *DBTransaction) error {
+		attempt++
+		tx.ExecuteWithStmt("liteInsertAlbum", 10, 10)
+		return errNoMoreData
+	})
+	if err != errNoMoreData || attempt != 1 {
+		t.Fatalf("permanent error should not be retried : %v, %d", err, attempt)
+	}
+
+	func() {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Fatalf("panic should be propagated")
+			}
+		}()
+		liteQueryManager.RunInTransaction(context.Background(), opts, func(tx *DBTransaction) error {
+			tx.ExecuteWithStmt("liteInsertAlbum", 20, 10)
+			panic("boom")
+		})
+	}()
+
+	if count := liteSelectAlbumCount(); count != 1 {
+		t.Fatalf("error and panic should roll back : %d", count)
+	}
+}
+
+func TestSqliteBeginWithOptions(t *testing.T) {
+	liteSetup(t)
+
+	opts := TxOptions{Isolation: sql.LevelSerializable, ReadOnly: true}
+	tx, err := liteQueryManager.BeginWithOptions(context.Background(), opts)
+	if err != nil {
+		t.Fatalf("fail to begin : %s", err.Error())
+	}
+	if _, err = tx.ExecuteWithStmt("liteInsertAlbum", 1, 10); err != ErrReadOnlyTransaction {
+		t.Fatalf("read only transaction should refuse execution : %v", err)
+	}
+	if _, err = tx.CreateBulkWithStmt("liteInsertAlbum"); err != ErrReadOnlyTransaction {
+		t.Fatalf("read only transaction should refuse bulk : %v", err)
+	}
+	count := 0
+	if err = tx.QueryRowWithStmt("liteSelectAlbumCount").Scan(&count); err != nil {
+		t.Fatalf("read only transaction should permit select : %s", err.Error())
+	}
+	tx.Rollback()
+
+	tx, err = liteQueryManager.BeginWithOptions(context.Background(), TxOptions{Timeout: time.Millisecond * 50})
+	if err != nil {
+		t.Fatalf("fail to begin : %s", err.Error())
+	}
+	if _, err = tx.ExecuteWithStmt("liteInsertAlbum", 1, 10); err != nil {
+		t.Fatalf("fail to insert : %s", err.Error())
+	}
+	time.Sleep(time.Millisecond * 100)
+	if err = tx.Commit(); err == nil {
+		t.Fatalf("transaction exceeding timeout should be rolled back")
+	}
+	if count := liteSelectAlbumCount(); count != 0 {
+		t.Fatalf("timed out transaction should not be committed : %d", count)
+	}
+}
+
+func TestSqliteTransactionTimeoutCancelsStatement(t *testing.T) {
+	liteSetup(t)
+
+	tx, err := liteQueryManager.BeginWithOptions(context.Background(), TxOptions{Timeout: time.Millisecond * 100})
+	if err != nil {
+		t.Fatalf("fail to begin : %s", err.Error())
+	}
+	defer tx.Rollback()
+
+	// statement of caller context should be cancelled by timeout of transaction
+	start := time.Now()
+	count := 0
+	err = tx.QueryRowWithStmtContext(context.Background(), "SELECT count(*) FROM (WITH RECURSIVE c(x) AS (SELECT 1 UNION ALL SELECT x+1 FROM c WHERE x < 100000000) SELECT x FROM c)").Scan(&count)
+	if err == nil {
+		t.Fatalf("running statement should be cancelled by transaction timeout : %d", count)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second * 3 {
+		t.Fatalf("statement should be cancelled at transaction timeout : %v", elapsed)
+	}
+}
+
+func TestSqliteNeverPropagation(t *testing.T) {
+	liteSetup(t)
+
+	committed := false
+	err := liteQueryManager.RunInTransaction(context.Background(), TxOptions{Propagation: PropagationNever}, func(tx *DBTransaction) error {
+		tx.OnCommit(func() { committed = true })
+		if _, err := tx.ExecuteWithStmt("liteInsertAlbum", 1, 10); err != nil {
+			return err
+		}
+		// statement is committed at once
+		if count := liteSelectAlbumCount(); count != 1 {
+			t.Errorf("statement of non transactional executor should be committed at once : %d", count)
+		}
+		count := 0
+		if err := tx.QueryRowWithStmtContext(tx.Context(), "liteSelectAlbumCount").Scan(&count); err != nil || count != 1 {
+			t.Errorf("fail to query in non transactional executor : count=%d, err=%v", count, err)
+		}
+		if _, ok := TxFromContext(tx.Context()); ok {
+			t.Errorf("context of non transactional executor should not carry transaction")
+		}
+		if _, err := tx.Begin(); err != ErrNotInTransaction {
+			t.Errorf("non transactional executor should refuse nested transaction : %v", err)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("fail to run never propagation : %s", err.Error())
+	}
+	if !committed {
+		t.Fatalf("commit hook of non transactional executor should run")
+	}
+
+	var cause error
+	err = liteQueryManager.RunInTransaction(context.Background(), TxOptions{Propagation: PropagationNever}, func(tx *DBTransaction) error {
+		tx.OnRollback(func(err error) { cause = err })
+		if _, err := tx.ExecuteWithStmt("liteInsertAlbum", 2, 20); err != nil {
+			return err
+		}
+		return errNoMoreData
+	})
+	if err != errNoMoreData || cause != errNoMoreData {
+		t.Fatalf("rollback hook should receive error of fn : err=%v, cause=%v", err, cause)
+	}
+	if count := liteSelectAlbumCount(); count != 2 {
+		t.Fatalf("statement of non transactional executor is not rolled back : %d", count)
+	}
+}
+
+func TestSqliteTransactionHooks(t *testing.T) {
+	liteSetup(t)
+
+	events := make([]string, 0)
+	tx, err := liteQueryManager.Begin()
+	if err != nil {
+		t.Fatalf("fail to begin : %s", err.Error())
+	}
+	tx.OnCommit(func() { events = append(events, "commit1") })
+	tx.OnCommit(func() { panic("hook panic") })
+	tx.OnCommit(func() { events = append(events, "commit2") })
+	tx.OnRollback(func(error) { events = append(events, "rollback") })
+
+	nested, _ := tx.Begin()
+	nested.OnCommit(func() { events = append(events, "nestedCommit") })
+	nested.Commit()
+	discarded, _ := tx.Begin()
+	discarded.OnCommit(func() { events = append(events, "discardedCommit") })
+	discarded.OnRollback(func(error) { events = append(events, "discardedRollback") })
+	discarded.Rollback()
+
+	if err = tx.Commit(); err != nil {
+		t.Fatalf("fail to commit : %s", err.Error())
+	}
+	tx.Rollback()
+	if fmt.Sprint(events) != "[discardedRollback commit1 commit2 nestedCommit]" {
+		t.Fatalf("invalid hook events : %v", events)
+	}
+
+	causes := make(chan error, 1)
+	err = liteQueryManager.RunInTransaction(context.Background(), TxOptions{}, func(tx *DBTransaction) error {
+		tx.OnRollback(func(cause error) { causes <- cause })
+		return errNoMoreData
+	})
+	if cause := <-causes; cause != errNoMoreData || err != errNoMoreData {
+		t.Fatalf("rollback hook should receive error of runner : %v", cause)
+	}
+}
+
+func TestSqliteTransactionFinalizerHook(t *testing.T) {
+	liteSetup(t)
+
+	// hook does not capture transaction. otherwise it is not guaranteed to be collected
+	causes := make(chan error, 1)
+	func() {
+		tx, err := liteQueryManager.Begin()
+		if err != nil {
+			t.Fatalf("fail to begin : %s", err.Error())
+		}
+		tx.OnRollback(func(cause error) { causes <- cause })
+		if _, err = tx.ExecuteWithStmt("liteInsertAlbum", 1, 10); err != nil {
+			t.Fatalf("fail to insert : %s", err.Error())
+		}
+	}()
+	for i := 0; i < 50; i++ {
+		runtime.GC()
+		select {
+		case cause := <-causes :
+			if cause != ErrTransactionNotClosed {
+				t.Fatalf("invalid rollback cause of finalizer : %v", cause)
+			}
+			if count := liteSelectAlbumCount(); count != 0 {
+				t.Fatalf("leaked transaction should not be committed : %d", count)
+			}
+			return
+		case <-time.After(time.Millisecond * 20) :
+		}
+	}
+	t.Fatalf("leaked transaction should be rolled back by finalizer")
+}
+
+func TestSqliteTransactionResultKeepsTransaction(t *testing.T) {
+	liteSetup(t)
+
+	causes := make(chan error, 1)
+	result := func() *QueryResult {
+		tx, err := liteQueryManager.Begin()
+		if err != nil {
+			t.Fatalf("fail to begin : %s", err.Error())
+		}
+		tx.OnRollback(func(cause error) { causes <- cause })
+		return tx.QueryWithStmt("SELECT v FROM (SELECT 1 AS v UNION ALL SELECT 2 UNION ALL SELECT 3)")
+	}()
+	if err := result.GetError(); err != nil {
+		t.Fatalf("fail to query : %s", err.Error())
+	}
+
+	// transaction should not be rolled back by finalizer while its rows are iterated
+	count := 0
+	for result.Next() {
+		count++
+		runtime.GC()
+		time.Sleep(time.Millisecond * 20)
+	}
+	if err := result.GetError(); err != nil || count != 3 {
+		t.Fatalf("rows of leaked transaction should be iterated : count=%d, err=%v", count, err)
+	}
+	select {
+	case cause := <-causes :
+		t.Fatalf("transaction should not be rolled back while result is open : %v", cause)
+	default :
+	}
+	result.Close()
+}
+
+func TestSqliteTransactionOpenScopeHooks(t *testing.T) {
+	liteSetup(t)
+
+	events := make([]string, 0)
+	tx, err := liteQueryManager.Begin()
+	if err != nil {
+		t.Fatalf("fail to begin : %s", err.Error())
+	}
+	tx.OnCommit(func() { events = append(events, "commit") })
+	nested, _ := tx.Begin()
+	nested.OnCommit(func() { events = append(events, "nestedCommit") })
+	inner, _ := nested.Begin()
+	inner.OnCommit(func() { events = append(events, "innerCommit") })
+
+	// open nested transactions are closed together with outer one
+	if err = tx.Commit(); err != nil {
+		t.Fatalf("fail to commit : %s", err.Error())
+	}
+	if fmt.Sprint(events) != "[commit nestedCommit innerCommit]" {
+		t.Fatalf("hooks of open nested transaction should run on commit : %v", events)
+	}
+	if err = nested.Commit(); err != sql.ErrTxDone {
+		t.Fatalf("nested transaction should be done with outer one : %v", err)
+	}
+
+	events = events[:0]
+	tx, err = liteQueryManager.Begin()
+	if err != nil {
+		t.Fatalf("fail to begin : %s", err.Error())
+	}
+	nested, _ = tx.Begin()
+	nested.OnCommit(func() { events = append(events, "nestedCommit") })
+	nested.OnRollback(func(error) { events = append(events, "nestedRollback") })
+	if err = tx.Rollback(); err != nil {
+		t.Fatalf("fail to rollback : %s", err.Error())
+	}
+	nested.Rollback()
+	if fmt.Sprint(events) != "[nestedRollback]" {
+		t.Fatalf("hooks of open nested transaction should run on rollback : %v", events)
+	}
+}
+
+func TestSqliteTransactionPropagation(t *testing.T) {
+	liteSetup(t)
+
+	outer, err := liteQueryManager.Begin()
+	if err != nil {
+		t.Fatalf("fail to begin : %s", err.Error())
+	}
+	defer outer.Rollback()
+	ctx := outer.Context()
+
+	if _, err = liteQueryManager.ExecuteWithStmtContext(ctx, "liteInsertAlbum", 1, 10); err != nil {
+		t.Fatalf("fail to insert : %s", err.Error())
+	}
+	countIn := func(ctx context.Context) int {
+		count := 0
+		if err := liteQueryManager.QueryRowWithStmtContext(ctx, "liteSelectAlbumCount").Scan(&count); err != nil {
+			t.Fatalf("fail to count : %s", err.Error())
+		}
+		return count
+	}
+	if count := countIn(ctx); count != 1 {
+		t.Fatalf("context aware query should join transaction : %d", count)
+	}
+
+	err = liteQueryManager.RunInTransaction(ctx, TxOptions{Propagation: PropagationRequired}, func(tx *DBTransaction) error {
+		if tx != outer {
+			t.Errorf("required propagation should join existing transaction")
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("fail to run required : %s", err.Error())
+	}
+
+	err = liteQueryManager.RunInTransaction(ctx, TxOptions{Propagation: PropagationNested}, func(tx *DBTransaction) error {
+		if _, err := liteQueryManager.ExecuteWithStmtContext(tx.Context(), "liteInsertAlbum", 2, 20); err != nil {
+			return err
+		}
+		return errNoMoreData
+	})
+	if err != errNoMoreData || countIn(ctx) != 1 {
+		t.Fatalf("nested propagation should roll back to savepoint : %v", err)
+	}
+
+	if err = liteQueryManager.RunInTransaction(ctx, TxOptions{Propagation: PropagationNever}, func(tx *DBTransaction) error {
+		return nil
+	}); err != ErrTransactionExists {
+		t.Fatalf("never propagation should refuse existing transaction : %v", err)
+	}
+
+	err = liteQueryManager.RunInTransaction(ctx, TxOptions{Propagation: PropagationRequiresNew}, func(tx *DBTransaction) error {
+		if tx == outer {
+			t.Errorf("requires new propagation should start new transaction")
+		}
+		if count := countIn(tx.Context()); count != 0 {
+			t.Errorf("new transaction should not see uncommitted row : %d", count)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("fail to run requires new : %s", err.Error())
+	}
+
+	outer.Rollback()
+	if count := countIn(context.Background()); count != 0 {
+		t.Fatalf("rolled back row should not exist : %d", count)
+	}
+}
+
+func TestSqliteBulkUpdate(t *testing.T) {
+	liteSetup(t)
+
+	if _, err := liteInsertAlbum([]AlbumData{{1, 10}, {2, 20}, {3, 30}}); err != nil {
+		t.Fatalf("fail to insert : %s", err.Error())
+	}
+
+	checkAffected := func(id string, args []interface{}, expected string) {
+		b, err := liteQueryManager.CreateBulkWithStmt(id)
+		if err != nil {
+			t.Fatalf("fail to create bulk : %s", err.Error())
+		}
+		if err = b.AddBatch(args); err != nil {
+			t.Fatalf("fail to add batch : %s", err.Error())
+		}
+		result, err := b.Execute()
+		if err != nil {
+			t.Fatalf("fail to execute bulk %s : %s", id, err.Error())
+		}
+		if affected := fmt.Sprint(result.(ExecMultiResult).GetAffectedList()); affected != expected {
+			t.Fatalf("invalid affected list of %s : %s", id, affected)
+		}
+	}
+
+	checkAffected("liteUpdateAlbumScore", []interface{}{AlbumData{1, 100}, AlbumData{2, 200}, AlbumData{9, 900}}, "[1 1 0]")
+	score := 0
+	if err := liteQueryManager.QueryRowWithStmt("SelectAlbumScore", 2).Scan(&score); err != nil || score != 200 {
+		t.Fatalf("album should be updated : %d, %v", score, err)
+	}
+
+	checkAffected("liteDeleteAlbum", []interface{}{map[string]interface{}{"Id": 1}, map[string]interface{}{"Id": 9}}, "[1 0]")
+	if count := liteSelectAlbumCount(); count != 2 {
+		t.Fatalf("album should be deleted : %d", count)
+	}
+
+	// IN clause array can't be bound per row
+	if _, err := liteQueryManager.CreateBulkWithStmt("DELETE FROM CITY WHERE NAME IN ( {Names} )"); err == nil {
+		t.Fatalf("bulk of IN clause array should be refused")
+	}
+	tx, err := liteQueryManager.Begin()
+	if err != nil {
+		t.Fatalf("fail to begin : %s", err.Error())
+	}
+	defer tx.Rollback()
+	if _, err = tx.CreateBulkWithStmt("DELETE FROM CITY WHERE NAME IN ( {Names} )"); err == nil {
+		t.Fatalf("bulk of IN clause array should be refused in transaction")
+	}
+}
+
+func TestSqliteBulkChunking(t *testing.T) {
+	liteSetup(t)
+
+	albums := make([]AlbumData, 0)
+	for i := 1; i <= 5; i++ {
+		albums = append(albums, AlbumData{i, i * 10})
+	}
+
+	insertChunked := func(opts BulkOptions, list []AlbumData) (ExecMultiResult, error) {
+		b, err := liteQueryManager.CreateBulkWithOptions(context.Background(), "liteInsertAlbum", opts)
+		if err != nil {
+			t.Fatalf("fail to create bulk : %s", err.Error())
+		}
+		if err = b.AddBatch(list); err != nil {
+			t.Fatalf("fail to add batch : %s", err.Error())
+		}
+		result, err := b.Execute()
+		if result == nil {
+			return ExecMultiResult{}, err
+		}
+		return result.(ExecMultiResult), err
+	}
+
+	opts := NewBulkOptions()
+	opts.MaxPlaceholders = 4
+	result, err := insertChunked(opts, albums)
+	if err != nil {
+		t.Fatalf("fail to insert : %s", err.Error())
+	}
+	affected, _ := result.RowsAffected()
+	if affected != 5 || result.GetChunkCount() != 3 {
+		t.Fatalf("invalid chunking : affected=%d, chunks=%d", affected, result.GetChunkCount())
+	}
+	if ids := fmt.Sprint(result.GetChunkInsertIdList()); ids != "[1 3 5]" {
+		t.Fatalf("invalid chunk insert ids : %s", ids)
+	}
+
+	liteSetup(t)
+	opts = NewBulkOptions()
+	opts.MaxBytes = 1
+	result, err = insertChunked(opts, albums)
+	if err != nil || result.GetChunkCount() != 5 {
+		t.Fatalf("each row should be a chunk when size limit is exceeded : %v, %d", err, result.GetChunkCount())
+	}
+
+	// duplicated id fails at the last chunk
+	liteSetup(t)
+	duplicated := append(albums, AlbumData{1, 10})
+	opts = NewBulkOptions()
+	opts.MaxPlaceholders = 4
+	opts.InTransaction = true
+	if _, err = insertChunked(opts, duplicated); err == nil {
+		t.Fatalf("duplicated id should fail")
+	}
+	if count := liteSelectAlbumCount(); count != 0 {
+		t.Fatalf("chunks in transaction should be rolled back : %d", count)
+	}
+
+	opts.InTransaction = false
+	result, err = insertChunked(opts, duplicated)
+	if err == nil || result.GetChunkCount() != 2 {
+		t.Fatalf("result should report chunks executed before failure : %v, %d", err, result.GetChunkCount())
+	}
+	if count := liteSelectAlbumCount(); count != 4 {
+		t.Fatalf("chunks before failure should be inserted : %d", count)
+	}
+}
+
+func TestSqliteBulkWriter(t *testing.T) {
+	liteSetup(t)
+
+	progress := make([]BulkProgress, 0)
+	opts := NewBulkWriterOptions()
+	opts.FlushRows = 2
+	opts.Progress = func(p BulkProgress) {
+		progress = append(progress, p)
+	}
+	writer, err := liteQueryManager.CreateBulkWriter(context.Background(), "liteInsertAlbum", opts)
+	if err != nil {
+		t.Fatalf("fail to create bulk writer : %s", err.Error())
+	}
+	for i := 1; i <= 5; i++ {
+		if err = writer.Write(AlbumData{i, i * 10}); err != nil {
+			t.Fatalf("fail to write : %s", err.Error())
+		}
+	}
+	if count := liteSelectAlbumCount(); count != 4 {
+		t.Fatalf("every 2 rows should be flushed : %d", count)
+	}
+	if err = writer.Close(); err != nil {
+		t.Fatalf("fail to close : %s", err.Error())
+	}
+	if len(progress) != 3 || progress[2].TotalRows != 5 || liteSelectAlbumCount() != 5 {
+		t.Fatalf("invalid progress : %v", progress)
+	}
+	if err = writer.Write(AlbumData{6, 60}); err != ErrBulkWriterClosed {
+		t.Fatalf("closed writer should refuse write : %v", err)
+	}
+
+	liteSetup(t)
+	opts = NewBulkWriterOptions()
+	opts.FlushRows = 2
+	opts.Async = true
+	opts.QueueSize = 1
+	writer, err = liteQueryManager.CreateBulkWriter(context.Background(), "liteInsertAlbum", opts)
+	if err != nil {
+		t.Fatalf("fail to create bulk writer : %s", err.Error())
+	}
+	for _, id := range []int{1, 2, 3, 1, 4, 5, 6} {
+		if err = writer.Write(AlbumData{id, id * 10}); err != nil {
+			t.Fatalf("fail to write : %s", err.Error())
+		}
+	}
+	if err = writer.Flush(); err == nil {
+		t.Fatalf("flush of duplicated id should fail")
+	}
+	if err = writer.Close(); err != nil {
+		t.Fatalf("error should be reported once : %s", err.Error())
+	}
+	errs := writer.Errors()
+	if len(errs) != 1 || errs[0].Flush != 2 || writer.TotalRows() != 5 {
+		t.Fatalf("invalid flush errors : %v, %d", errs, writer.TotalRows())
+	}
+
+	liteSetup(t)
+	opts = NewBulkWriterOptions()
+	opts.FlushRows = 0
+	opts.FlushInterval = time.Millisecond * 20
+	writer, err = liteQueryManager.CreateBulkWriter(context.Background(), "liteInsertAlbum", opts)
+	if err != nil {
+		t.Fatalf("fail to create bulk writer : %s", err.Error())
+	}
+	defer writer.Close()
+	writer.Write(AlbumData{1, 10})
+	for i := 0; i < 50 && liteSelectAlbumCount() == 0; i++ {
+		time.Sleep(time.Millisecond * 10)
+	}
+	if count := liteSelectAlbumCount(); count != 1 {
+		t.Fatalf("rows should be flushed periodically : %d", count)
+	}
+}
+
+func TestSqliteUpsert(t *testing.T) {
+	liteSetup(t)
+
+	if _, err := liteQueryManager.ExecuteWithStmt("liteUpsertAlbumScore", AlbumData{1, 10}); err != nil {
+		t.Fatalf("fail to upsert : %s", err.Error())
+	}
+	if _, err := liteQueryManager.ExecuteWithStmt("liteUpsertAlbumScore", AlbumData{1, 20}); err != nil {
+		t.Fatalf("fail to upsert : %s", err.Error())
+	}
+
+	bulk, err := liteQueryManager.CreateBulkWithStmt("liteUpsertAlbumScore")
+	if err != nil {
+		t.Fatalf("fail to create bulk : %s", err.Error())
+	}
+	if err = bulk.AddBatch([]AlbumData{{2, 30}, {3, 40}}); err != nil {
+		t.Fatalf("fail to add batch : %s", err.Error())
+	}
+	if _, err = bulk.Execute(); err != nil {
+		t.Fatalf("fail to execute bulk upsert : %s", err.Error())
+	}
+
+	if count := liteSelectAlbumCount(); count != 3 {
+		t.Fatalf("upsert should not duplicate row : %d", count)
+	}
+	score := 0
+	if err = liteQueryManager.QueryRowWithStmt("SelectAlbumScore", 1).Scan(&score); err != nil || score != 20 {
+		t.Fatalf("album should be updated : %d, %v", score, err)
+	}
+
+	// updated row gets no new id, so ids should not be derived and written back
+	bulk, err = liteQueryManager.CreateBulkWithStmt("liteUpsertAlbumScoreWithKey")
+	if err != nil {
+		t.Fatalf("fail to create bulk : %s", err.Error())
+	}
+	updated, inserted := &AlbumData{1, 50}, &AlbumData{5, 60}
+	if err = bulk.AddBatch(updated, inserted); err != nil {
+		t.Fatalf("fail to add batch : %s", err.Error())
+	}
+	result, err := bulk.Execute()
+	if err != nil {
+		t.Fatalf("fail to execute bulk upsert : %s", err.Error())
+	}
+	if updated.Id != 1 || inserted.Id != 5 {
+		t.Fatalf("keyProperty of upsert should not be overwritten : %d, %d", updated.Id, inserted.Id)
+	}
+	if ids := result.(ExecMultiResult).GetInsertIdList(); len(ids) != 0 {
+		t.Fatalf("insert ids of upsert should not be derived : %v", ids)
+	}
+
+	// ignored row gets no new id either
+	bulk, err = liteQueryManager.CreateBulkWithStmt("liteInsertOrIgnoreAlbumWithKey")
+	if err != nil {
+		t.Fatalf("fail to create bulk : %s", err.Error())
+	}
+	ignored, inserted := &AlbumData{1, 70}, &AlbumData{7, 80}
+	if err = bulk.AddBatch(ignored, inserted); err != nil {
+		t.Fatalf("fail to add batch : %s", err.Error())
+	}
+	if _, err = bulk.Execute(); err != nil {
+		t.Fatalf("fail to execute bulk insert : %s", err.Error())
+	}
+	if ignored.Id != 1 || inserted.Id != 7 {
+		t.Fatalf("keyProperty of insert ignoring conflict should not be overwritten : %d, %d", ignored.Id, inserted.Id)
+	}
+}
+
+// stepDialect queries insert id step with stepQuery
+type stepDialect struct {
+	sqliteDialect
+	stepQuery string
+}
+
+func (d stepDialect) InsertIdStepQuery() string {
+	return d.stepQuery
+}
+
+func TestSqliteInsertIdStepCache(t *testing.T) {
+	liteSetup(t)
+
+	bulk := newQuerymanBulk(liteQueryManager.primary(context.Background()), QueryStatement{}, NewBulkOptions())
+	defer insertIdSteps.remove(liteQueryManager.db)
+	step, err := bulk.insertIdStep(stepDialect{stepQuery: "SELECT 2"})
+	if err != nil || step != 2 {
+		t.Fatalf("fail to get insert id step : %d, %v", step, err)
+	}
+
+	// step is queried once per connection pool
+	step, err = bulk.insertIdStep(stepDialect{stepQuery: "SELECT broken FROM"})
+	if err != nil || step != 2 {
+		t.Fatalf("insert id step should be cached : %d, %v", step, err)
+	}
+	insertIdSteps.remove(liteQueryManager.db)
+	if _, err = bulk.insertIdStep(stepDialect{stepQuery: "SELECT broken FROM"}); err == nil {
+		t.Fatalf("removed insert id step should be queried again")
+	}
+}
+
+// liteLoader inserts rows one by one to check dispatching of registered native loader
+type liteLoader struct {
+	calls int
+}
+
+func (l *liteLoader) Supports(drv driver.Driver) bool {
+	return drv != nil
+}
+
+func (l *liteLoader) Load(conn NativeConn, table string, columns []string, rows [][]interface{}) (int64, error) {
+	l.calls++
+	marks := strings.TrimSuffix(strings.Repeat("?,", len(columns)), ",")
+	pstmt, err := conn.Prepare(fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s)", table, strings.Join(columns, ", "), marks))
+	if err != nil {
+		return 0, err
+	}
+	defer pstmt.Close()
+
+	for _, row := range rows {
+		if _, err = pstmt.Exec(row...); err != nil {
+			return 0, err
+		}
+	}
+	return int64(len(rows)), nil
+}
+
+func TestSqliteBulkNativeLoader(t *testing.T) {
+	liteSetup(t)
+
+	loader := &liteLoader{}
+	RegisterNativeLoader("sqlite", loader)
+	defer RegisterNativeLoader("sqlite", nil)
+
+	opts := NewBulkOptions()
+	opts.Mode = BulkModeNative
+	bulk, err := liteQueryManager.CreateBulkWithOptions(context.Background(), "liteInsertAlbum", opts)
+	if err != nil {
+		t.Fatalf("fail to create bulk : %s", err.Error())
+	}
+	for i := 1; i <= 10; i++ {
+		bulk.AddBatch(AlbumData{i, i * 10})
+	}
+	result, err := bulk.Execute()
+	if err != nil {
+		t.Fatalf("fail to load : %s", err.Error())
+	}
+	if affected, _ := result.RowsAffected(); affected != 10 || loader.calls != 1 {
+		t.Fatalf("rows should be loaded by registered loader : %d, %d", affected, loader.calls)
+	}
+	if count := liteSelectAlbumCount(); count != 10 {
+		t.Fatalf("invalid album count : %d", count)
+	}
+}
+
+func TestSqliteBulkUserQuery(t *testing.T) {
+	liteSetup(t)
+
+	bulk, err := liteQueryManager.CreateBulkWithStmt("INSERT INTO album(id, score) VALUES (?, ?)")
+	if err != nil {
+		t.Fatalf("fail to create bulk : %s", err.Error())
+	}
+	if err = bulk.AddBatch(1, 10); err != nil {
+		t.Fatalf("fail to add batch of user query : %s", err.Error())
+	}
+	if err = bulk.AddBatch([]interface{}{2, 20}); err != nil {
+		t.Fatalf("fail to add batch of user query : %s", err.Error())
+	}
+	if _, err = bulk.Execute(); err != nil {
+		t.Fatalf("fail to execute bulk of user query : %s", err.Error())
+	}
+	if count := liteSelectAlbumCount(); count != 2 {
+		t.Fatalf("invalid album count : %d", count)
+	}
+}
+
+func TestSqliteBulkReuse(t *testing.T) {
+	liteSetup(t)
+
+	bulk, err := liteQueryManager.CreateBulkWithStmt("liteInsertAlbum")
+	if err != nil {
+		t.Fatalf("fail to create bulk : %s", err.Error())
+	}
+	for round := 0; round < 3; round++ {
+		for i := 1; i <= 2; i++ {
+			if err = bulk.AddBatch(AlbumData{round*10 + i, i}); err != nil {
+				t.Fatalf("fail to add batch : %s", err.Error())
+			}
+		}
+		result, err := bulk.ExecuteAndReset()
+		if err != nil {
+			t.Fatalf("fail to execute round %d : %s", round, err.Error())
+		}
+		if affected, _ := result.RowsAffected(); affected != 2 || bulk.Len() != 0 {
+			t.Fatalf("each round should insert its rows only : %d, %d", affected, bulk.Len())
+		}
+	}
+
+	if count := liteSelectAlbumCount(); count != 6 {
+		t.Fatalf("invalid album count : %d", count)
+	}
+
+	// successful Execute clears rows, so rows are not sent again
+	for round := 3; round < 5; round++ {
+		if err = bulk.AddBatch(AlbumData{round*10 + 1, 1}); err != nil {
+			t.Fatalf("fail to add batch : %s", err.Error())
+		}
+		result, err := bulk.Execute()
+		if err != nil {
+			t.Fatalf("fail to execute round %d : %s", round, err.Error())
+		}
+		if affected, _ := result.RowsAffected(); affected != 1 || bulk.Len() != 0 {
+			t.Fatalf("executed rows should be cleared : %d, %d", affected, bulk.Len())
+		}
+	}
+
+	// rows of failed Execute are kept until Reset
+	if err = bulk.AddBatch(AlbumData{1, 1}); err != nil {
+		t.Fatalf("fail to add batch : %s", err.Error())
+	}
+	if _, err = bulk.Execute(); err == nil || bulk.Len() != 1 {
+		t.Fatalf("rows of failed execute should be kept : %v, %d", err, bulk.Len())
+	}
+	bulk.Reset()
+
+	if count := liteSelectAlbumCount(); count != 8 {
+		t.Fatalf("invalid album count : %d", count)
+	}
+}
+
+func TestSqliteExecuteBatch(t *testing.T) {
+	albums := []interface{}{AlbumData{1, 10}, AlbumData{1, 20}, map[string]interface{}{"Id": 3}, []interface{}{4, 40}}
+
+	liteSetup(t)
+	result, err := liteQueryManager.ExecuteBatch(context.Background(), "liteInsertAlbum", albums, BatchFailFast)
+	if err == nil || len(result.Items()) != 2 || result.Items()[1].Err == nil {
+		t.Fatalf("batch should stop at the first failed item : %v, %v", err, result.Items())
+	}
+	if count := liteSelectAlbumCount(); count != 1 {
+		t.Fatalf("invalid album count : %d", count)
+	}
+
+	liteSetup(t)
+	result, err = liteQueryManager.ExecuteBatch(context.Background(), "liteInsertAlbum", albums, BatchContinue)
+	if err != ErrBatchItemFailed || len(result.Items()) != 4 {
+		t.Fatalf("batch should continue : %v, %v", err, result.Items())
+	}
+	failed := result.Failed()
+	if len(failed) != 2 || failed[0].Index != 1 || failed[1].Index != 2 {
+		t.Fatalf("invalid failed items : %v", failed)
+	}
+	if affected, _ := result.RowsAffected(); affected != 2 || result.Items()[3].RowsAffected != 1 {
+		t.Fatalf("invalid affected count : %d", affected)
+	}
+
+	liteSetup(t)
+	_, err = liteQueryManager.ExecuteBatch(context.Background(), "liteInsertAlbum", albums, BatchInTransaction)
+	if err == nil {
+		t.Fatalf("batch in transaction should be failed")
+	}
+	if count := liteSelectAlbumCount(); count != 0 {
+		t.Fatalf("batch should be rolled back : %d", count)
+	}
+
+	// failed item is rolled back to savepoint in transaction
+	liteSetup(t)
+	tx, err := liteQueryManager.Begin()
+	if err != nil {
+		t.Fatalf("fail to begin : %s", err.Error())
+	}
+	result, err = tx.ExecuteBatch(context.Background(), "liteInsertAlbum", albums, BatchContinue)
+	if err != ErrBatchItemFailed || len(result.Failed()) != 2 {
+		t.Fatalf("batch should continue in transaction : %v", err)
+	}
+	if err = tx.Commit(); err != nil {
+		t.Fatalf("fail to commit : %s", err.Error())
+	}
+	if count := liteSelectAlbumCount(); count != 2 {
+		t.Fatalf("succeeded items should be committed : %d", count)
+	}
+
+	// batch in existing transaction is rolled back to savepoint as a whole
+	liteSetup(t)
+	tx, err = liteQueryManager.Begin()
+	if err != nil {
+		t.Fatalf("fail to begin : %s", err.Error())
+	}
+	if _, err = tx.ExecuteWithStmt("liteInsertAlbum", 9, 90); err != nil {
+		t.Fatalf("fail to insert : %s", err.Error())
+	}
+	if _, err = tx.ExecuteBatch(context.Background(), "liteInsertAlbum", albums, BatchInTransaction); err == nil {
+		t.Fatalf("batch in transaction should be failed")
+	}
+	if _, err = tx.ExecuteBatch(context.Background(), "liteInsertAlbum", []AlbumData{{5, 50}}, BatchInTransaction); err != nil {
+		t.Fatalf("transaction should be usable after failed batch : %s", err.Error())
+	}
+	if err = tx.Commit(); err != nil {
+		t.Fatalf("fail to commit : %s", err.Error())
+	}
+	if count := liteSelectAlbumCount(); count != 2 {
+		t.Fatalf("items of failed batch should be rolled back in transaction : %d", count)
+	}
+
+	// dynamic statement can't be prepared once for every item
+	_, err = liteQueryManager.ExecuteBatch(context.Background(), "liteUpdateAlbumScoreWithIf", []AlbumData{{1, 30}}, BatchFailFast)
+	if err != ErrBatchDynamicStatement {
+		t.Fatalf("batch of dynamic statement should be rejected : %v", err)
+	}
+}
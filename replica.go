@@ -0,0 +1,188 @@
+//
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+//
+// @project queryman
+// @author agent
+// @date 2026. 10. 18. PM 11:50
+//
+
+package queryman
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"net"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ReplicaBalance decides which replica serves select statement
+type ReplicaBalance int
+
+const (
+	ReplicaRoundRobin ReplicaBalance = iota
+	ReplicaLeastConn
+)
+
+type replica struct {
+	db          *sql.DB
+	healthy     int32
+}
+
+func (r *replica) isHealthy() bool {
+	return atomic.LoadInt32(&r.healthy) == 1
+}
+
+func (r *replica) setHealthy(healthy bool) {
+	if healthy {
+		atomic.StoreInt32(&r.healthy, 1)
+	} else {
+		atomic.StoreInt32(&r.healthy, 0)
+	}
+}
+
+// replicaSet balances select statements over read replicas.
+// failing replica is ejected and admitted again when health check succeeds
+type replicaSet struct {
+	replicas    []*replica
+	balance     ReplicaBalance
+	next        uint32
+	stopChan    chan struct{}
+	stopOnce    sync.Once
+}
+
+func newReplicaSet(pref QuerymanPreference) (*replicaSet, error) {
+	set := &replicaSet{}
+	set.balance = pref.ReplicaBalance
+	set.replicas = make([]*replica, 0, len(pref.replicaSourceUrls))
+	set.stopChan = make(chan struct{})
+
+	for _, url := range pref.replicaSourceUrls {
+		db, err := sql.Open(pref.DriverName, url)
+		if err != nil {
+			set.close()
+			return nil, err
+		}
+		db.SetConnMaxLifetime(pref.ConnMaxLifetime)
+		db.SetMaxOpenConns(pref.MaxOpenConns)
+		db.SetMaxIdleConns(pref.MaxIdleConns)
+		set.replicas = append(set.replicas, &replica{db: db})
+	}
+
+	set.check()
+	if pref.ReplicaHealthCheckInterval > 0 {
+		go set.healthCheck(pref.ReplicaHealthCheckInterval)
+	}
+	return set, nil
+}
+
+// pick returns healthy replica. nil if every replica is ejected
+func (s *replicaSet) pick() *replica {
+	count := len(s.replicas)
+	if count == 0 {
+		return nil
+	}
+
+	if s.balance == ReplicaLeastConn {
+		var picked *replica
+		inUse := 0
+		for _, v := range s.replicas {
+			if !v.isHealthy() {
+				continue
+			}
+			if stats := v.db.Stats(); picked == nil || stats.InUse < inUse {
+				picked = v
+				inUse = stats.InUse
+			}
+		}
+		return picked
+	}
+
+	start := int(atomic.AddUint32(&s.next, 1))
+	for i := 0; i < count; i++ {
+		v := s.replicas[(start+i)%count]
+		if v.isHealthy() {
+			return v
+		}
+	}
+	return nil
+}
+
+func (s *replicaSet) check() {
+	for _, v := range s.replicas {
+		v.setHealthy(v.db.Ping() == nil)
+	}
+}
+
+func (s *replicaSet) healthCheck(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.stopChan :
+			return
+		case <-ticker.C :
+			s.check()
+		}
+	}
+}
+
+func (s *replicaSet) close() {
+	s.stopOnce.Do(func() {
+		close(s.stopChan)
+		for _, v := range s.replicas {
+			v.db.Close()
+		}
+	})
+}
+
+// isConnectionError reports whether err means datasource is unreachable
+func isConnectionError(err error) bool {
+	if err == nil {
+		return false
+	}
+	if err == driver.ErrBadConn {
+		return true
+	}
+	_, ok := err.(net.Error)
+	return ok
+}
+
+// queryRead runs select statement on replica. primary serves it when statement or call demands master,
+// or when no replica is available. replica failing with connection error is ejected
+func (man *QueryMan) queryRead(ctx context.Context, stmt QueryStatement, v ...interface{}) *QueryResult {
+	if man.replicas == nil || man.useMaster || stmt.UseMaster {
+		return queryMultiRow(man.primary(ctx), stmt, v...)
+	}
+
+	picked := man.replicas.pick()
+	if picked == nil {
+		return queryMultiRow(man.primary(ctx), stmt, v...)
+	}
+
+	queryedRow := queryMultiRow(contextProxy{man, picked.db, ctx, false, picked.db}, stmt, v...)
+	if isConnectionError(queryedRow.err) {
+		picked.setHealthy(false)
+		man.debugPrint("replica ejected : %s", queryedRow.err.Error())
+		return queryMultiRow(man.primary(ctx), stmt, v...)
+	}
+	return queryedRow
+}
@@ -31,10 +31,42 @@ import (
 )
 
 type QueryResult struct {
+	tx                 *DBTransaction	// keeps transaction from finalizer while rows are iterated
 	pstmt              *sql.Stmt
 	err                error
 	rows               *sql.Rows
 	fieldNameConverter FieldNameConvertStrategy
+	resultCheck        resultChecker
+}
+
+// resultChecker validates selected columns with resultType of statement once
+type resultChecker struct {
+	stmtId     string
+	resultDecl *statementType
+	checked    bool
+}
+
+func (c *resultChecker) bind(stmt QueryStatement) {
+	c.stmtId = stmt.Id
+	c.resultDecl = stmt.resultDecl
+}
+
+func (c *resultChecker) check(rows *sql.Rows, converter FieldNameConvertStrategy, dest []interface{}) error {
+	if c.resultDecl == nil || c.checked {
+		return nil
+	}
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return err
+	}
+
+	err = checkResultColumns(c.stmtId, c.resultDecl, converter, columns, dest)
+	if err != nil {
+		return err
+	}
+	c.checked = true
+	return nil
 }
 
 func newQueryResultError(err error) *QueryResult {
@@ -91,6 +123,11 @@ func (r *QueryResult) Scan(v ...interface{}) (err error) {
 		return ErrNilPtr
 	}
 
+	err = r.resultCheck.check(r.rows, r.fieldNameConverter, v)
+	if err != nil {
+		return err
+	}
+
 	atype = atype.Elem()
 	val := reflect.ValueOf(v[0]).Elem()
 
@@ -141,10 +178,12 @@ func (r *QueryResult) Close() error {
 
 type QueryRowResult struct {
 	transaction 		bool
+	tx                 *DBTransaction	// keeps transaction from finalizer while rows are iterated
 	pstmt              *sql.Stmt
 	err                error
 	rows               *sql.Rows
 	fieldNameConverter FieldNameConvertStrategy
+	resultCheck        resultChecker
 }
 
 func newQueryRowResultError(err error) *QueryRowResult {
@@ -208,6 +247,11 @@ func (r *QueryRowResult) Scan(v ...interface{}) (err error) {
 		return ErrNilPtr
 	}
 
+	err = r.resultCheck.check(r.rows, r.fieldNameConverter, v)
+	if err != nil {
+		return err
+	}
+
 	atype = atype.Elem()
 	val := reflect.ValueOf(v[0]).Elem()
 
@@ -239,6 +283,24 @@ func (r *QueryRowResult) scanToStruct(val *reflect.Value) error {
 type ExecMultiResult struct {
 	idList			[]int64
 	rowAffected		int64
+	affectedList	[]int64
+	chunkRows		[]int64
+	chunkIdList		[]int64
+}
+
+func (p *ExecMultiResult) addAffected(affected int64)  {
+	p.affectedList = append(p.affectedList, affected)
+}
+
+// GetAffectedList returns affected count of each executed row
+func (p ExecMultiResult) GetAffectedList() []int64  {
+	return p.affectedList
+}
+
+func (p *ExecMultiResult) merge(next ExecMultiResult)  {
+	p.idList = append(p.idList, next.idList...)
+	p.rowAffected += next.rowAffected
+	p.affectedList = append(p.affectedList, next.affectedList...)
 }
 
 func (p *ExecMultiResult) addInsertId(id int64)  {
@@ -255,6 +317,10 @@ func (p ExecMultiResult) GetInsertIdList() []int64  {
 
 func (p ExecMultiResult) LastInsertId() (int64, error) {
 	if p.idList == nil || len(p.idList) == 0 {
+		// bulk insert collects the first generated id of each chunk
+		if len(p.chunkIdList) > 0 {
+			return p.chunkIdList[0], nil
+		}
 		return 0, ErrNoInsertId
 	}
 
@@ -264,3 +330,13 @@ func (p ExecMultiResult) LastInsertId() (int64, error) {
 func (p ExecMultiResult) RowsAffected() (int64, error) {
 	return p.rowAffected, nil
 }
+
+// GetChunkCount returns number of statements executed by bulk insert
+func (p ExecMultiResult) GetChunkCount() int {
+	return len(p.chunkRows)
+}
+
+// GetChunkInsertIdList returns the first generated id of each bulk insert chunk
+func (p ExecMultiResult) GetChunkInsertIdList() []int64 {
+	return p.chunkIdList
+}
@@ -0,0 +1,218 @@
+//
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+//
+// @project queryman
+// @author agent
+// @date 2026. 10. 18. PM 11:53
+//
+
+package queryman
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"reflect"
+	"time"
+)
+
+// Propagation decides how transaction runner treats transaction carried by context
+type Propagation int
+
+const (
+	// PropagationRequired joins existing transaction or starts new one
+	PropagationRequired Propagation = iota
+	// PropagationRequiresNew always starts new transaction
+	PropagationRequiresNew
+	// PropagationNested runs in savepoint of existing transaction or starts new one
+	PropagationNested
+	// PropagationNever runs without transaction and fails if transaction exists
+	PropagationNever
+)
+
+// TxOptions configures transaction. Timeout rolls back transaction when exceeded.
+// MaxRetries, RetryBackoff and Propagation are used by transaction runner
+type TxOptions struct {
+	Isolation    sql.IsolationLevel
+	ReadOnly     bool
+	Timeout      time.Duration
+	MaxRetries   int
+	RetryBackoff time.Duration
+	Propagation  Propagation
+}
+
+func (opts TxOptions) String() string {
+	return fmt.Sprintf("isolation=%s, readOnly=%t, timeout=%v", opts.Isolation.String(), opts.ReadOnly, opts.Timeout)
+}
+
+// NewTxOptions returns options retrying 3 times. backoff starts with 50 milliseconds and doubles per retry
+func NewTxOptions() TxOptions {
+	opts := TxOptions{}
+	opts.MaxRetries = 3
+	opts.RetryBackoff = time.Duration(time.Millisecond * 50)
+	return opts
+}
+
+// RunInTransaction runs fn in a transaction. it commits when fn returns nil,
+// rolls back when fn returns error or panics. whole fn is retried on deadlock or serialization failure.
+// transaction carried by ctx is treated by opts.Propagation. joined transaction is not retried,
+// and fn receives non transactional executor with PropagationNever. use tx.Context() to pass the transaction to other layers
+func (man *QueryMan) RunInTransaction(ctx context.Context, opts TxOptions, fn func(tx *DBTransaction) error) error {
+	existing := man.joinedTx(ctx)
+	switch opts.Propagation {
+	case PropagationRequired :
+		if existing != nil {
+			return fn(existing)
+		}
+	case PropagationNested :
+		if existing != nil {
+			return runNestedTransaction(existing, fn)
+		}
+	case PropagationNever :
+		if existing != nil {
+			return ErrTransactionExists
+		}
+		return runWithTransaction(man.newNonTransaction(ctx), fn)
+	}
+
+	for attempt := 0; ; attempt++ {
+		err := man.runTransaction(ctx, opts, fn)
+		if err == nil || !isRetryableError(err) || attempt >= opts.MaxRetries {
+			return err
+		}
+
+		backoff := opts.RetryBackoff << uint(attempt)
+		man.debugPrint("transaction retry %d after %v : %s", attempt+1, backoff, err.Error())
+		select {
+		case <-ctx.Done() :
+			return ctx.Err()
+		case <-time.After(backoff) :
+		}
+	}
+}
+
+// newNonTransaction returns executor whose statements are committed at once.
+// its Commit and Rollback only run hooks, and savepoint or nested transaction fails with ErrNotInTransaction
+func (man *QueryMan) newNonTransaction(ctx context.Context) *DBTransaction {
+	transaction := newTransaction(man, nil, man, man.fieldNameConverter, man.Dialect())
+	transaction.source = man.db
+	transaction.ctx = ctx
+	return transaction
+}
+
+func (man *QueryMan) runTransaction(ctx context.Context, opts TxOptions, fn func(tx *DBTransaction) error) error {
+	tx, err := man.BeginWithOptions(ctx, opts)
+	if err != nil {
+		return err
+	}
+	return runWithTransaction(tx, fn)
+}
+
+func runNestedTransaction(existing *DBTransaction, fn func(tx *DBTransaction) error) error {
+	nested, err := existing.Begin()
+	if err != nil {
+		return err
+	}
+	return runWithTransaction(nested, fn)
+}
+
+// runWithTransaction commits tx when fn succeeds, rolls back otherwise
+func runWithTransaction(tx *DBTransaction, fn func(tx *DBTransaction) error) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			tx.rollbackWith(fmt.Errorf("panic : %v", r))
+			panic(r)
+		}
+	}()
+
+	err = fn(tx)
+	if err != nil {
+		tx.rollbackWith(err)
+		return err
+	}
+	return tx.Commit()
+}
+
+// error types of drivers. matched by package path so that queryman does not import drivers
+const (
+	mysqlDriverPackage		= "github.com/go-sql-driver/mysql"
+	pqDriverPackage			= "github.com/lib/pq"
+	pgconnPackage			= "github.com/jackc/pgconn"
+	pgxPgconnPackage		= "github.com/jackc/pgx/v5/pgconn"
+)
+
+// isRetryableError reports deadlock or lock wait timeout of MySQL (1213, 1205)
+// and serialization failure or deadlock of PostgreSQL (40001, 40P01)
+func isRetryableError(err error) bool {
+	for ; err != nil; err = errors.Unwrap(err) {
+		errType := reflect.TypeOf(err)
+		if errType.Kind() != reflect.Ptr || errType.Elem().Kind() != reflect.Struct {
+			continue
+		}
+
+		val := reflect.ValueOf(err).Elem()
+		switch errType.Elem().PkgPath() + "." + errType.Elem().Name() {
+		case mysqlDriverPackage + ".MySQLError" :
+			// *mysql.MySQLError
+			if number := val.FieldByName("Number"); number.IsValid() && number.Kind() == reflect.Uint16 {
+				if number.Uint() == 1213 || number.Uint() == 1205 {
+					return true
+				}
+			}
+		case pqDriverPackage + ".Error", pgconnPackage + ".PgError", pgxPgconnPackage + ".PgError" :
+			// *pq.Error, *pgconn.PgError
+			if s, ok := err.(interface{ SQLState() string }); ok && isRetryableSqlState(s.SQLState()) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func isRetryableSqlState(state string) bool {
+	return state == "40001" || state == "40P01"
+}
+
+// BeginWithOptions starts transaction with isolation level and read only flag.
+// transaction is rolled back when ctx is done or Timeout is exceeded
+func (man *QueryMan) BeginWithOptions(ctx context.Context, opts TxOptions) (*DBTransaction, error) {
+	// ctx of transaction is always cancelled at finish so that statement contexts derived from it are released
+	cancel := context.CancelFunc(nil)
+	if opts.Timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, opts.Timeout)
+	} else {
+		ctx, cancel = context.WithCancel(ctx)
+	}
+
+	if man.debugEnabled() {
+		man.debugPrint("begin transaction : %s", opts.String())
+	}
+
+	tx, err := man.db.BeginTx(ctx, &sql.TxOptions{Isolation: opts.Isolation, ReadOnly: opts.ReadOnly})
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	transaction := man.newTransaction(tx)
+	transaction.readOnly = opts.ReadOnly
+	transaction.cancel = cancel
+	transaction.ctx = ctx
+	return transaction, nil
+}
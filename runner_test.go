@@ -0,0 +1,76 @@
+//
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+//
+// @project queryman
+// @author agent
+// @date 2026. 10. 18. PM 11:53
+//
+
+package queryman
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/lib/pq"
+)
+
+// lookalikeError has same fields with driver errors but it is not a database error
+type lookalikeError struct {
+	Number	uint16
+	Code	string
+}
+
+func (e *lookalikeError) Error() string	{
+	return fmt.Sprintf("lookalike %d %s", e.Number, e.Code)
+}
+
+func (e *lookalikeError) SQLState() string	{
+	return e.Code
+}
+
+func TestRetryableError(t *testing.T) {
+	retryable := []error{
+		&mysql.MySQLError{Number: 1213, Message: "Deadlock found when trying to get lock"},
+		&mysql.MySQLError{Number: 1205, Message: "Lock wait timeout exceeded"},
+		&pq.Error{Code: "40001"},
+		&pq.Error{Code: "40P01"},
+		fmt.Errorf("fail to update : %w", &mysql.MySQLError{Number: 1213}),
+		fmt.Errorf("bulk [%s] : %w", "Insert", fmt.Errorf("fail to execute chunk %d/%d : %w", 2, 3, &pq.Error{Code: "40P01"})),
+	}
+	for _, v := range retryable {
+		if !isRetryableError(v) {
+			t.Errorf("%v should be retryable", v)
+		}
+	}
+
+	permanent := []error{
+		&mysql.MySQLError{Number: 1062, Message: "Duplicate entry"},
+		&pq.Error{Code: "23505"},
+		errors.New("40001"),
+		&lookalikeError{Number: 1213, Code: "40001"},
+		nil,
+	}
+	for _, v := range permanent {
+		if isRetryableError(v) {
+			t.Errorf("%v should not be retryable", v)
+		}
+	}
+}
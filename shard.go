@@ -0,0 +1,310 @@
+//
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+//
+// @project queryman
+// @author agent
+// @date 2026. 10. 18. PM 11:51
+//
+
+package queryman
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"hash/fnv"
+	"reflect"
+	"runtime"
+	"sync"
+)
+
+// ShardFunc returns shard index of statement parameter
+type ShardFunc func(param interface{}, shardCount int) (int, error)
+
+// ShardByKey returns ShardFunc which hashes value of key in struct or map parameter.
+// integer value is divided by shard count, others are hashed with fnv.
+// bare value parameter is hashed itself
+func ShardByKey(key string) ShardFunc {
+	return func(param interface{}, shardCount int) (index int, err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = fmt.Errorf("fail to find shard key : %s", r)
+			}
+		}()
+
+		value, err := findShardKey(key, param)
+		if err != nil {
+			return 0, err
+		}
+		return shardIndex(value, shardCount), nil
+	}
+}
+
+func findShardKey(key string, param interface{}) (interface{}, error) {
+	val := reflect.ValueOf(param)
+	if val.Kind() == reflect.Ptr {
+		if val.IsNil() {
+			return nil, ErrNilPtr
+		}
+		val = val.Elem()
+	}
+
+	var m map[string]interface{}
+	switch val.Kind() {
+	case reflect.Map :
+		m = flattenToMap(val.Interface())
+	case reflect.Struct :
+		if _, is := val.Interface().(driver.Valuer); is {
+			return val.Interface(), nil
+		}
+		m = flattenStructToMap(val.Interface())
+	case reflect.Slice, reflect.Array :
+		if val.Kind() == reflect.Slice && val.Type().Elem().Kind() == reflect.Uint8 {
+			return val.Interface(), nil
+		}
+		if val.Len() == 0 {
+			return nil, ErrNoShardKey
+		}
+		return findShardKey(key, val.Index(0).Interface())
+	default :
+		return val.Interface(), nil
+	}
+
+	if value, ok := m[key]; ok {
+		return value, nil
+	}
+	return nil, fmt.Errorf("%s : %s", ErrNoShardKey.Error(), key)
+}
+
+func shardIndex(value interface{}, shardCount int) int {
+	val := reflect.ValueOf(value)
+	switch val.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64 :
+		n := val.Int() % int64(shardCount)
+		if n < 0 {
+			n = -n
+		}
+		return int(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64 :
+		return int(val.Uint() % uint64(shardCount))
+	}
+
+	h := fnv.New32a()
+	switch v := value.(type) {
+	case string :
+		h.Write([]byte(v))
+	case []byte :
+		h.Write(v)
+	default :
+		h.Write([]byte(fmt.Sprint(v)))
+	}
+	return int(h.Sum32() % uint32(shardCount))
+}
+
+// ShardedQueryMan routes statements to one of datasources by shard key of parameter.
+// nested list parameter is routed by its first element, so every element should belong to same shard
+type ShardedQueryMan struct {
+	shards    []*QueryMan
+	shardFunc ShardFunc
+}
+
+func NewShardedQueryman(prefs []QuerymanPreference, shardFunc ShardFunc) (*ShardedQueryMan, error) {
+	if len(prefs) == 0 {
+		return nil, fmt.Errorf("empty shard preference")
+	}
+	if shardFunc == nil {
+		return nil, fmt.Errorf("nil shard func")
+	}
+
+	sharded := &ShardedQueryMan{}
+	sharded.shardFunc = shardFunc
+	sharded.shards = make([]*QueryMan, 0, len(prefs))
+	for i, pref := range prefs {
+		man, err := NewQueryman(pref)
+		if err != nil {
+			sharded.Close()
+			return nil, fmt.Errorf("fail to create shard %d : %s", i, err.Error())
+		}
+		sharded.shards = append(sharded.shards, man)
+	}
+	return sharded, nil
+}
+
+func (s *ShardedQueryMan) ShardCount() int {
+	return len(s.shards)
+}
+
+// Shard returns QueryMan of shard index
+func (s *ShardedQueryMan) Shard(index int) *QueryMan {
+	return s.shards[index]
+}
+
+// Route returns QueryMan of the shard which parameter belongs to
+func (s *ShardedQueryMan) Route(param interface{}) (*QueryMan, error) {
+	index, err := s.shardFunc(param, len(s.shards))
+	if err != nil {
+		return nil, err
+	}
+	if index < 0 || index >= len(s.shards) {
+		return nil, fmt.Errorf("invalid shard index : %d", index)
+	}
+	return s.shards[index], nil
+}
+
+func (s *ShardedQueryMan) route(v []interface{}) (*QueryMan, error) {
+	if len(v) == 0 {
+		return nil, ErrNoShardKey
+	}
+	return s.Route(v[0])
+}
+
+func (s *ShardedQueryMan) Close() error {
+	var err error
+	for _, v := range s.shards {
+		if closeErr := v.Close(); closeErr != nil {
+			err = closeErr
+		}
+	}
+	return err
+}
+
+func (s *ShardedQueryMan) Execute(v ...interface{}) (sql.Result, error) {
+	pc, _, _, _ := runtime.Caller(1)
+	funcName := findFunctionName(pc)
+	return s.ExecuteWithStmt(funcName, v...)
+}
+
+func (s *ShardedQueryMan) ExecuteWithStmt(stmtIdOrUserQuery string, v ...interface{}) (sql.Result, error) {
+	man, err := s.route(v)
+	if err != nil {
+		return nil, err
+	}
+	return man.ExecuteWithStmt(stmtIdOrUserQuery, v...)
+}
+
+func (s *ShardedQueryMan) Query(v ...interface{}) *QueryResult {
+	pc, _, _, _ := runtime.Caller(1)
+	funcName := findFunctionName(pc)
+	return s.QueryWithStmt(funcName, v...)
+}
+
+func (s *ShardedQueryMan) QueryWithStmt(stmtIdOrUserQuery string, v ...interface{}) *QueryResult {
+	man, err := s.route(v)
+	if err != nil {
+		return newQueryResultError(err)
+	}
+	return man.QueryWithStmt(stmtIdOrUserQuery, v...)
+}
+
+func (s *ShardedQueryMan) QueryRow(v ...interface{}) *QueryRowResult {
+	pc, _, _, _ := runtime.Caller(1)
+	funcName := findFunctionName(pc)
+	return s.QueryRowWithStmt(funcName, v...)
+}
+
+func (s *ShardedQueryMan) QueryRowWithStmt(stmtIdOrUserQuery string, v ...interface{}) *QueryRowResult {
+	man, err := s.route(v)
+	if err != nil {
+		return newQueryRowResultError(err)
+	}
+	return man.QueryRowWithStmt(stmtIdOrUserQuery, v...)
+}
+
+// Begin starts transaction on the shard which param belongs to
+func (s *ShardedQueryMan) Begin(param interface{}) (*DBTransaction, error) {
+	man, err := s.Route(param)
+	if err != nil {
+		return nil, err
+	}
+	return man.Begin()
+}
+
+// QueryAllShards runs select statement on every shard concurrently and merges rows shard by shard
+func (s *ShardedQueryMan) QueryAllShards(stmtIdOrUserQuery string, v ...interface{}) *ShardQueryResult {
+	merged := &ShardQueryResult{}
+	merged.results = make([]*QueryResult, len(s.shards))
+
+	var wg sync.WaitGroup
+	for i, man := range s.shards {
+		wg.Add(1)
+		go func(index int, man *QueryMan) {
+			defer wg.Done()
+			merged.results[index] = man.QueryWithStmt(stmtIdOrUserQuery, v...)
+		}(i, man)
+	}
+	wg.Wait()
+
+	for i, v := range merged.results {
+		if v.err != nil {
+			merged.err = fmt.Errorf("shard %d : %s", i, v.err.Error())
+			merged.Close()
+			break
+		}
+	}
+	return merged
+}
+
+// ShardQueryResult iterates rows of every shard
+type ShardQueryResult struct {
+	results []*QueryResult
+	current int
+	err     error
+}
+
+func (r *ShardQueryResult) Next() bool {
+	if r.err != nil {
+		return false
+	}
+
+	for r.current < len(r.results) {
+		if r.results[r.current].Next() {
+			return true
+		}
+		r.current++
+	}
+	return false
+}
+
+// Shard returns shard index of current row
+func (r *ShardQueryResult) Shard() int {
+	return r.current
+}
+
+func (r *ShardQueryResult) GetError() error {
+	return r.err
+}
+
+func (r *ShardQueryResult) Scan(v ...interface{}) error {
+	if r.err != nil {
+		return r.err
+	}
+	if r.current >= len(r.results) {
+		return ErrNoRows
+	}
+	return r.results[r.current].Scan(v...)
+}
+
+func (r *ShardQueryResult) Close() error {
+	var err error
+	for _, v := range r.results {
+		if closeErr := v.Close(); closeErr != nil {
+			err = closeErr
+		}
+	}
+	return err
+}
@@ -33,6 +33,11 @@ import (
 	)
 
 func execute(sqlProxy SqlProxy, stmt QueryStatement, v ...interface{}) (result sql.Result, err error) {
+	err = checkParameter(stmt, v...)
+	if err != nil {
+		return
+	}
+
 	execStmt, err := refineConditional(stmt, v...)
 	if err != nil {
 		err = fmt.Errorf("fail to buld conditional query : %s", err.Error())
@@ -43,7 +48,7 @@ func execute(sqlProxy SqlProxy, stmt QueryStatement, v ...interface{}) (result s
 		if sqlProxy.debugEnabled() {
 			sqlProxy.debugPrint("%s", stmt.Debug())
 		}
-		return sqlProxy.exec(execStmt.Query)
+		return execStatement(sqlProxy, execStmt, execStmt.Query)
 	}
 
 	defer func() {
@@ -118,7 +123,7 @@ func execWithMap(sqlProxy SqlProxy, stmt QueryStatement, m map[string]interface{
 		sqlProxy.debugPrint("%s", stmt.Debug(param...))
 	}
 
-	return sqlProxy.exec(effectiveQuery, param...)
+	return execStatement(sqlProxy, stmt, effectiveQuery, param...)
 }
 
 func execWithList(sqlProxy SqlProxy, stmt QueryStatement, args []interface{}) (sql.Result, error) {
@@ -146,7 +151,7 @@ func execWithList(sqlProxy SqlProxy, stmt QueryStatement, args []interface{}) (s
 			sqlProxy.recordExcution(stmt.Id, start)
 		} ()
 
-		return sqlProxy.exec(effectiveQuery, param...)
+		return execStatement(sqlProxy, stmt, effectiveQuery, param...)
 	}
 
 	// check nested list
@@ -161,8 +166,9 @@ func execWithList(sqlProxy SqlProxy, stmt QueryStatement, args []interface{}) (s
 		return execWithNestedMap(sqlProxy, stmt, args)
 	}
 
-	if len(stmt.columnMention) > len(args) {
-		return nil, fmt.Errorf("binding parameter count mismatch. defined=%d, args=%d", len(stmt.columnMention), len(args))
+	args, err := stmt.fillMissingArgs(args)
+	if err != nil {
+		return nil, err
 	}
 
 	if sqlProxy.debugEnabled() {
@@ -173,7 +179,87 @@ func execWithList(sqlProxy SqlProxy, stmt QueryStatement, args []interface{}) (s
 	defer func() {
 		sqlProxy.recordExcution(stmt.Id, start)
 	} ()
-	return sqlProxy.exec(stmt.Query, args...)
+	return execStatement(sqlProxy, stmt, stmt.Query, stmt.bindArgs(stmt.holdNames(), args)...)
+}
+
+// execStatement executes query. insert statement having RETURNING clause is queried
+// to collect generated keys
+func execStatement(sqlProxy SqlProxy, stmt QueryStatement, query string, args ...interface{}) (sql.Result, error) {
+	if !stmt.returning {
+		return sqlProxy.exec(query, args...)
+	}
+
+	rows, err := sqlProxy.query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	result := ExecMultiResult{}
+	err = scanReturningRows(rows, &result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// execPrepared executes prepared statement and accumulates affected count and generated key into result
+func execPrepared(pstmt *sql.Stmt, stmt QueryStatement, result *ExecMultiResult, args ...interface{}) error {
+	args = stmt.bindArgs(stmt.holdNames(), args)
+	if stmt.returning {
+		rows, err := pstmt.Query(args...)
+		if err != nil {
+			return err
+		}
+		before := result.rowAffected
+		err = scanReturningRows(rows, result)
+		result.addAffected(result.rowAffected - before)
+		return err
+	}
+
+	res, err := pstmt.Exec(args...)
+	if err != nil {
+		return err
+	}
+	affectedCount, _ := res.RowsAffected()
+	result.rowAffected += affectedCount
+	result.addAffected(affectedCount)
+
+	if stmt.collectInsertId() {
+		id, err := res.LastInsertId()
+		if err != nil {
+			return fmt.Errorf("fail to get last inserted id : %s", err.Error())
+		}
+		result.addInsertId(id)
+	}
+	return nil
+}
+
+// scanReturningRows reads first column of RETURNING rows as generated key
+func scanReturningRows(rows *sql.Rows, result *ExecMultiResult) error {
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return err
+	}
+	if len(columns) == 0 {
+		return ErrNoInsertId
+	}
+
+	var id int64
+	dest := make([]interface{}, len(columns))
+	dest[0] = &id
+	for i:=1; i<len(columns); i++ {
+		dest[i] = new(sql.RawBytes)
+	}
+
+	for rows.Next() {
+		if err := rows.Scan(dest...); err != nil {
+			return fmt.Errorf("fail to get returning key : %s", err.Error())
+		}
+		result.addInsertId(id)
+		result.rowAffected++
+	}
+	return rows.Err()
 }
 
 
@@ -183,8 +269,7 @@ func execWithNestedList(sqlProxy SqlProxy, stmt QueryStatement, args []interface
 		var nextResult ExecMultiResult
 		_, nextResult, err = doExecWithNestedList(sqlProxy, stmt, args[executed:])
 		if err == nil {
-			result.idList = append(result.idList, nextResult.idList...)
-			result.rowAffected += nextResult.rowAffected
+			result.merge(nextResult)
 		}
 	}
 	return result, err
@@ -196,7 +281,7 @@ func doExecWithNestedList(sqlProxy SqlProxy, stmt QueryStatement, args []interfa
 		if reflect.TypeOf(v).Kind() != reflect.Slice && reflect.TypeOf(v).Kind() != reflect.Array {
 			return 0, ExecMultiResult{}, fmt.Errorf("nested listing structure should have slice type data only. %d=%s", i, reflect.TypeOf(v).String())
 		}
-		if len(stmt.columnMention) > reflect.ValueOf(v).Len() {
+		if !stmt.acceptArgCount(reflect.ValueOf(v).Len()) {
 			return 0, ExecMultiResult{}, fmt.Errorf("binding parameter count mismatch. defined=%d, args[%d]=%d", len(stmt.columnMention), i, reflect.ValueOf(v).Len())
 		}
 	}
@@ -210,7 +295,10 @@ func doExecWithNestedList(sqlProxy SqlProxy, stmt QueryStatement, args []interfa
 	sqlProxy.debugPrint("[%s] %s", stmt.Id, stmt.Query)
 	result := ExecMultiResult{}
 	for i, v := range args {
-		passing := flattenToList(v)
+		passing, err := stmt.fillMissingArgs(flattenToList(v))
+		if err != nil {
+			return i, result, err
+		}
 
 		if sqlProxy.debugEnabled() {
 			var buffer bytes.Buffer
@@ -222,21 +310,11 @@ func doExecWithNestedList(sqlProxy SqlProxy, stmt QueryStatement, args []interfa
 		}
 
 		start := time.Now()
-		res, err := pstmt.Exec(passing...)
+		err = execPrepared(pstmt, stmt, &result, passing...)
 		if err != nil {
 			return i, result, err
 		}
 		sqlProxy.recordExcution(stmt.Id, start)
-		affectedCount, _ := res.RowsAffected()
-		result.rowAffected += affectedCount
-
-		if stmt.eleType == eleTypeInsert {
-			id, err := res.LastInsertId()
-			if err != nil {
-				return i, result, fmt.Errorf("fail to get last inserted id : %s", err.Error())
-			}
-			(&result).addInsertId(id)
-		}
 	}
 
 	return len(args), result, nil
@@ -248,8 +326,7 @@ func execWithNestedMap(sqlProxy SqlProxy, stmt QueryStatement, args []interface{
 		var nextResult ExecMultiResult
 		_, nextResult, err = doExecWithNestedMap(sqlProxy, stmt, args[executed:])
 		if err == nil {
-			result.idList = append(result.idList, nextResult.idList...)
-			result.rowAffected += nextResult.rowAffected
+			result.merge(nextResult)
 		}
 	}
 	return result, err
@@ -261,8 +338,8 @@ func doExecWithNestedMap(sqlProxy SqlProxy, stmt QueryStatement, args []interfac
 		if reflect.TypeOf(v).Kind() != reflect.Map {
 			return 0, ExecMultiResult{}, fmt.Errorf("nested listing structure should have map type data only. %d=%s", i, reflect.TypeOf(v).String())
 		}
-		if len(stmt.columnMention) > reflect.ValueOf(v).Len() {
-			return 0, ExecMultiResult{}, fmt.Errorf("binding parameter count mismatch. defined=%d, args[%d]=%d", len(stmt.columnMention), i, reflect.ValueOf(v).Len())
+		if stmt.requiredBindCount() > reflect.ValueOf(v).Len() {
+			return 0, ExecMultiResult{}, fmt.Errorf("binding parameter count mismatch. defined=%d, args[%d]=%d", stmt.requiredBindCount(), i, reflect.ValueOf(v).Len())
 		}
 	}
 
@@ -281,13 +358,9 @@ func doExecWithNestedMap(sqlProxy SqlProxy, stmt QueryStatement, args []interfac
 			return i, result, ErrInvalidMapType
 		}
 
-		param := make([]interface{}, 0)
-		for _,v2 := range stmt.columnMention {
-			found, ok := m[v2.Name()]
-			if !ok {
-				return i, result, fmt.Errorf("not found \"%s\" from map", v)
-			}
-			param = append(param, found)
+		param, err := stmt.bindMap(m)
+		if err != nil {
+			return i, result, err
 		}
 
 		if sqlProxy.debugEnabled() {
@@ -300,21 +373,11 @@ func doExecWithNestedMap(sqlProxy SqlProxy, stmt QueryStatement, args []interfac
 		}
 
 		start := time.Now()
-		res, err := pstmt.Exec(param...)
+		err = execPrepared(pstmt, stmt, &result, param...)
 		if err != nil {
 			return i, result, err
 		}
 		sqlProxy.recordExcution(stmt.Id, start)
-		affectedCount, _ := res.RowsAffected()
-		result.rowAffected += affectedCount
-
-		if stmt.eleType == eleTypeInsert {
-			id, err := res.LastInsertId()
-			if err != nil {
-				return i, result, fmt.Errorf("fail to get last inserted id : %s", err.Error())
-			}
-			(&result).addInsertId(id)
-		}
 	}
 
 	return len(args), result, nil
@@ -327,8 +390,7 @@ func execWithStructList(sqlProxy SqlProxy, stmt QueryStatement, args []interface
 		var nextResult ExecMultiResult
 		_, nextResult, err = doExecWithStructList(sqlProxy, stmt, args[executed:])
 		if err == nil {
-			result.idList = append(result.idList, nextResult.idList...)
-			result.rowAffected += nextResult.rowAffected
+			result.merge(nextResult)
 		}
 	}
 	return result, err
@@ -356,15 +418,9 @@ func doExecWithStructList(sqlProxy SqlProxy, stmt QueryStatement, args []interfa
 			val = reflect.ValueOf(v).Elem().Interface()
 		}
 
-		m := flattenStructToMap(val)
-		param := make([]interface{}, 0)
-
-		for _,v := range stmt.columnMention {
-			found, ok := m[v.Name()]
-			if !ok {
-				return i, result, fmt.Errorf("doExecWithStructList : not found \"%s\" from parameter values", v)
-			}
-			param = append(param, found)
+		param, err := stmt.bindMap(flattenStructToMap(val))
+		if err != nil {
+			return i, result, err
 		}
 
 		if sqlProxy.debugEnabled() {
@@ -377,21 +433,11 @@ func doExecWithStructList(sqlProxy SqlProxy, stmt QueryStatement, args []interfa
 		}
 
 		start := time.Now()
-		res, err := pstmt.Exec(param...)
+		err = execPrepared(pstmt, stmt, &result, param...)
 		if err != nil {
 			return i, result, err
 		}
 		sqlProxy.recordExcution(stmt.Id, start)
-		affectedCount, _ := res.RowsAffected()
-		result.rowAffected += affectedCount
-
-		if stmt.eleType == eleTypeInsert {
-			id, err := res.LastInsertId()
-			if err != nil {
-				return i, result, fmt.Errorf("fail to get last inserted id : %s", err.Error())
-			}
-			(&result).addInsertId(id)
-		}
 	}
 
 	return len(args), result, nil
@@ -438,6 +484,11 @@ func flattenStructToMap(s interface{}) map[string]interface{} {
 
 
 func queryMultiRow(sqlProxy SqlProxy, stmt QueryStatement, v ...interface{}) (queryedRow *QueryResult) {
+	err := checkParameter(stmt, v...)
+	if err != nil {
+		return newQueryResultError(err)
+	}
+
 	execStmt, err := refineConditional(stmt, v...)
 	if err != nil {
 		return newQueryResultError(fmt.Errorf("fail to buld conditional query : %s", err.Error()))
@@ -550,8 +601,9 @@ func queryWithList(sqlProxy SqlProxy, stmt QueryStatement, args []interface{}) *
 		}
 	}
 
-	if len(stmt.columnMention) > len(args) {
-		return newQueryResultError(fmt.Errorf("binding parameter count mismatch. defined=%d, args=%d", len(stmt.columnMention), len(args)))
+	args, err := stmt.fillMissingArgs(args)
+	if err != nil {
+		return newQueryResultError(err)
 	}
 
 	effectiveQuery, param, bindErr := resolveColumnBindInList(stmt, args)
@@ -581,51 +633,50 @@ func queryWithObject(sqlProxy SqlProxy, stmt QueryStatement, parameter interface
 }
 
 func resolveColumnBindInMap(stmt QueryStatement, m map[string]interface{}) (string, []interface{}, *QueryResult)	{
-	param := make([]interface{}, 0)
 	if !stmt.hasArrayBind() {
-		for _,v := range stmt.columnMention {
-			found, ok := m[v.Name()]
-			if !ok {
-				return stmt.Query, param, newQueryResultError(fmt.Errorf("queryWithMap : not found \"%s\" from parameter values", v))
-			}
-			param = append(param, found)
+		param, err := stmt.bindMap(m)
+		if err != nil {
+			return stmt.Query, param, newQueryResultError(err)
 		}
-		return stmt.Query, param, nil
+		return stmt.Query, stmt.bindArgs(stmt.holdNames(), param), nil
 	}
 
+	param := make([]interface{}, 0)
+	names := make([]string, 0)
+
 	clone := stmt.clone()
 	effectiveQuery := clone.Query
 	holdedQuery := clone.HoldedQuery
 
-	touch := false
+	expansion := make([]arrayExpansion, 0)
 	for _, v := range clone.columnMention {
-		found := m[v.Name()]
+		found, ok := v.valueFrom(m)
+		if !ok {
+			return effectiveQuery, param, newQueryResultError(newStatementTypeError(stmt.Id, v.Name(), "not found from parameter values"))
+		}
 		if v.bindType == columnBindTypeNormal {
 			param = append(param, found)
+			names = append(names, v.Name())
 			continue
 		}
 
 		if v.bindType == columnBindTypeArray {
 			arr, cnt := flattenArray(found)
 			param = append(param, arr...)
+			names = append(names, arrayBindNames(v.Name(), len(arr))...)
 			if cnt > 1 {
-				if touch {
-					return effectiveQuery,
-						param,
-						newQueryResultError(fmt.Errorf("this version only support 1 IN array binding"))
-				}
-				holdedQuery = reformHoldQuery(holdedQuery, v, cnt)
-				touch = true
+				expansion = append(expansion, arrayExpansion{bind: v, cnt: cnt})
 			}
 			continue
 		}
 		param = append(param, found)
+		names = append(names, v.Name())
 	}
 
-	if touch {
-		effectiveQuery = queryNormalizer.resolveHolding(holdedQuery)
+	if len(expansion) > 0 {
+		effectiveQuery = stmt.resolveHolding(reformHoldQueryAll(holdedQuery, expansion), names)
 	}
-	return effectiveQuery, param, nil
+	return effectiveQuery, stmt.bindArgs(names, param), nil
 
 	//fmt.Printf("resolveColumnBindInMap : %s\n", stmt.Id)
 	//for _,v := range stmt.columnMention {
@@ -641,47 +692,73 @@ func resolveColumnBindInMap(stmt QueryStatement, m map[string]interface{}) (stri
 
 func resolveColumnBindInList(stmt QueryStatement, args []interface{}) (string, []interface{}, error)	{
 	if !stmt.hasArrayBind() {
-		return stmt.Query, args, nil
+		return stmt.Query, stmt.bindArgs(stmt.holdNames(), args), nil
 	}
 
 	clone := stmt.clone()
 	param := make([]interface{}, 0)
+	names := make([]string, 0)
 	effectiveQuery := clone.Query
 	holdedQuery := clone.HoldedQuery
 
-	if len(clone.columnMention) > len(args) {
-		return effectiveQuery, param, fmt.Errorf("binding parameter count mismatch. defined=%d, args=%d", len(stmt.columnMention), len(args))
+	args, err := clone.fillMissingArgs(args)
+	if err != nil {
+		return effectiveQuery, param, err
 	}
 
-	touch := false
+	expansion := make([]arrayExpansion, 0)
 	for i, v := range clone.columnMention {
 		found := args[i]
 		if v.bindType == columnBindTypeNormal {
 			param = append(param, found)
+			names = append(names, v.Name())
 			continue
 		}
 
 		if v.bindType == columnBindTypeArray {
 			arr, cnt := flattenArray(found)
 			param = append(param, arr...)
+			names = append(names, arrayBindNames(v.Name(), len(arr))...)
 			if cnt > 1 {
-				if touch {
-					return effectiveQuery,
-					param,
-					fmt.Errorf("this version only support 1 IN array binding")
-				}
-				holdedQuery = reformHoldQuery(holdedQuery, v, cnt)
-				touch = true
+				expansion = append(expansion, arrayExpansion{bind: v, cnt: cnt})
 			}
 			continue
 		}
 		param = append(param, found)
+		names = append(names, v.Name())
 	}
 
-	if touch {
-		effectiveQuery = queryNormalizer.resolveHolding(holdedQuery)
+	if len(expansion) > 0 {
+		effectiveQuery = stmt.resolveHolding(reformHoldQueryAll(holdedQuery, expansion), names)
 	}
-	return effectiveQuery, param, nil
+	return effectiveQuery, stmt.bindArgs(names, param), nil
+}
+
+// arrayBindNames names each element of IN array for named binding. e.g) Ids_1, Ids_2
+func arrayBindNames(name string, cnt int) []string {
+	if cnt < 2 {
+		return []string{name}
+	}
+
+	names := make([]string, cnt)
+	for i:=0; i<cnt; i++ {
+		names[i] = fmt.Sprintf("%s_%d", name, i+1)
+	}
+	return names
+}
+
+type arrayExpansion struct {
+	bind ColumnBind
+	cnt  int
+}
+
+// reformHoldQueryAll expands IN array holds from the last one
+// so that hold position of preceding binds is kept
+func reformHoldQueryAll(holdQuery string, expansion []arrayExpansion) string {
+	for i:=len(expansion)-1; i>=0; i-- {
+		holdQuery = reformHoldQuery(holdQuery, expansion[i].bind, expansion[i].cnt)
+	}
+	return holdQuery
 }
 
 func reformHoldQuery(holdQuery string, columnBind ColumnBind, cnt int) string {
@@ -731,12 +808,8 @@ func flattenArray(v interface{}) ([]interface{}, int) {
 	}
 
 	if slice, ok := val.([]interface{}); ok  {
-		varCnt = 0
-		for i, item := range slice {
-			param = append(param, item)
-			varCnt = i
-		}
-		return param, varCnt
+		param = append(param, slice...)
+		return param, len(slice)
 	}
 
 	s := reflect.ValueOf(v)
@@ -0,0 +1,631 @@
+//
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+//
+// @project queryman
+// @author agent
+// @date 2026. 10. 18. PM 11:12
+//
+
+package queryman
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+)
+
+/*
+statement type declaration
+
+	<insert id="InsertCity" paramType="City">				registered go type (see RegisterType)
+	<select id="SelectCity" resultType="{Id:int,Name:string}">	inline column types
+*/
+
+const (
+	columnKindAny = iota
+	columnKindString
+	columnKindInt
+	columnKindUint
+	columnKindFloat
+	columnKindBool
+	columnKindTime
+	columnKindBytes
+)
+
+type columnKind uint8
+
+func (k columnKind) String() string {
+	switch k {
+	case columnKindAny :	return "any"
+	case columnKindString :	return "string"
+	case columnKindInt :	return "int"
+	case columnKindUint :	return "uint"
+	case columnKindFloat :	return "float"
+	case columnKindBool :	return "bool"
+	case columnKindTime :	return "time"
+	case columnKindBytes :	return "bytes"
+	}
+	return "unknown"
+}
+
+// accept reports whether value of kind v can be bound to the declared kind k
+func (k columnKind) accept(v columnKind) bool {
+	if k == columnKindAny || v == columnKindAny || k == v {
+		return true
+	}
+
+	switch k {
+	case columnKindInt, columnKindUint :
+		return v == columnKindInt || v == columnKindUint
+	case columnKindFloat :
+		return v == columnKindInt || v == columnKindUint || v == columnKindFloat
+	}
+	return false
+}
+
+// goType returns go type expression used by code generator
+func (k columnKind) goType() string {
+	switch k {
+	case columnKindString :	return "string"
+	case columnKindInt :	return "int64"
+	case columnKindUint :	return "uint64"
+	case columnKindFloat :	return "float64"
+	case columnKindBool :	return "bool"
+	case columnKindTime :	return "time.Time"
+	case columnKindBytes :	return "[]byte"
+	}
+	return "interface{}"
+}
+
+func buildColumnKind(name string) (columnKind, error) {
+	switch strings.ToLower(name) {
+	case "any", "interface{}" :
+		return columnKindAny, nil
+	case "string", "text", "varchar" :
+		return columnKindString, nil
+	case "int", "int8", "int16", "int32", "int64", "integer", "long" :
+		return columnKindInt, nil
+	case "uint", "uint8", "uint16", "uint32", "uint64" :
+		return columnKindUint, nil
+	case "float", "float32", "float64", "double", "decimal" :
+		return columnKindFloat, nil
+	case "bool", "boolean" :
+		return columnKindBool, nil
+	case "time", "time.time", "datetime", "timestamp", "date" :
+		return columnKindTime, nil
+	case "bytes", "[]byte", "blob" :
+		return columnKindBytes, nil
+	}
+	return columnKindAny, fmt.Errorf("unknown column type : %s", name)
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+var bytesType = reflect.TypeOf([]byte{})
+
+func kindOfType(t reflect.Type) columnKind {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	if t == timeType {
+		return columnKindTime
+	}
+	if t == bytesType {
+		return columnKindBytes
+	}
+	if reflect.PtrTo(t).Implements(reflect.TypeOf((*sql.Scanner)(nil)).Elem()) {
+		return columnKindAny
+	}
+
+	switch t.Kind() {
+	case reflect.String :
+		return columnKindString
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64 :
+		return columnKindInt
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64 :
+		return columnKindUint
+	case reflect.Float32, reflect.Float64 :
+		return columnKindFloat
+	case reflect.Bool :
+		return columnKindBool
+	}
+	return columnKindAny
+}
+
+// kindOfValue returns kind of binding value. nil (NULL) returns false
+func kindOfValue(v interface{}) (columnKind, bool) {
+	if v == nil {
+		return columnKindAny, false
+	}
+
+	if valuer, ok := v.(driver.Valuer); ok {
+		rv := reflect.ValueOf(v)
+		if rv.Kind() == reflect.Ptr && rv.IsNil() {
+			return columnKindAny, false
+		}
+		value, err := valuer.Value()
+		if err != nil {
+			return columnKindAny, true
+		}
+		if value == nil {
+			return columnKindAny, false
+		}
+		return kindOfType(reflect.TypeOf(value)), true
+	}
+
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return columnKindAny, false
+		}
+		rv = rv.Elem()
+	}
+	return kindOfType(rv.Type()), true
+}
+
+type typedColumn struct {
+	name string
+	kind columnKind
+}
+
+// statementType is parsed paramType/resultType declaration of statement
+type statementType struct {
+	declare  string
+	typeName string
+	columns  []typedColumn
+}
+
+func (s *statementType) String() string {
+	return s.declare
+}
+
+func (s *statementType) isInline() bool {
+	return len(s.typeName) == 0
+}
+
+func (s *statementType) findColumn(name string) (typedColumn, bool) {
+	for _, v := range s.columns {
+		if strings.EqualFold(v.name, name) {
+			return v, true
+		}
+	}
+	return typedColumn{}, false
+}
+
+// registeredType returns go type of declaration. nil if inline or not registered yet
+func (s *statementType) registeredType() reflect.Type {
+	if s.isInline() {
+		return nil
+	}
+	return findRegisteredType(s.typeName)
+}
+
+func parseStatementType(declare string) (*statementType, error) {
+	declare = strings.Trim(declare, cutset)
+	if len(declare) == 0 {
+		return nil, nil
+	}
+
+	decl := &statementType{}
+	decl.declare = declare
+	decl.columns = make([]typedColumn, 0)
+	if !strings.HasPrefix(declare, delimStartString) {
+		decl.typeName = strings.TrimLeft(declare, "*[]")
+		return decl, nil
+	}
+
+	if !strings.HasSuffix(declare, delimStopString) {
+		return nil, fmt.Errorf("invalid type declaration : %s", declare)
+	}
+
+	for _, v := range strings.Split(declare[1:len(declare)-1], ",") {
+		v = strings.Trim(v, cutset)
+		if len(v) == 0 {
+			continue
+		}
+		pair := strings.SplitN(v, ":", 2)
+		name := strings.Trim(pair[0], cutset)
+		if len(pair) != 2 || len(name) == 0 {
+			return nil, fmt.Errorf("invalid column declaration [%s] in %s", v, declare)
+		}
+		kind, err := buildColumnKind(strings.Trim(pair[1], cutset))
+		if err != nil {
+			return nil, fmt.Errorf("%s in %s", err.Error(), declare)
+		}
+		if _, exist := decl.findColumn(name); exist {
+			return nil, fmt.Errorf("duplicated column [%s] in %s", name, declare)
+		}
+		decl.columns = append(decl.columns, typedColumn{name: name, kind: kind})
+	}
+
+	return decl, nil
+}
+
+var typeRegistry = struct {
+	sync.RWMutex
+	types map[string]reflect.Type
+}{types: make(map[string]reflect.Type)}
+
+// RegisterType registers go type of v with name. statements can declare
+// registered name as paramType or resultType
+//
+//	queryman.RegisterType("City", City{})
+func RegisterType(name string, v interface{}) {
+	t := reflect.TypeOf(v)
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	typeRegistry.Lock()
+	defer typeRegistry.Unlock()
+	typeRegistry.types[name] = t
+}
+
+func findRegisteredType(name string) reflect.Type {
+	typeRegistry.RLock()
+	defer typeRegistry.RUnlock()
+
+	if t, ok := typeRegistry.types[name]; ok {
+		return t
+	}
+	// try without package qualifier. e.g) model.City
+	if dot := strings.LastIndexByte(name, '.'); dot >= 0 {
+		return typeRegistry.types[name[dot+1:]]
+	}
+	return nil
+}
+
+// StatementTypeError reports parameter or result which doesn't match with
+// paramType/resultType declaration of statement
+type StatementTypeError struct {
+	StmtId string
+	Field  string
+	Reason string
+}
+
+func (e *StatementTypeError) Error() string {
+	if len(e.Field) == 0 {
+		return fmt.Sprintf("stmt [%s] : %s", e.StmtId, e.Reason)
+	}
+	return fmt.Sprintf("stmt [%s] field [%s] : %s", e.StmtId, e.Field, e.Reason)
+}
+
+func newStatementTypeError(stmtId string, field string, format string, args ...interface{}) *StatementTypeError {
+	e := &StatementTypeError{}
+	e.StmtId = stmtId
+	e.Field = field
+	e.Reason = fmt.Sprintf(format, args...)
+	return e
+}
+
+// checkParameter validates execution parameters with paramType declaration of statement
+func checkParameter(stmt QueryStatement, v ...interface{}) error {
+	decl := stmt.paramDecl
+	if decl == nil {
+		return nil
+	}
+
+	if len(v) == 0 {
+		if decl.isInline() && len(decl.columns) == 0 {
+			return nil
+		}
+		return newStatementTypeError(stmt.Id, "", "paramType %s declared but no parameter passed", decl)
+	}
+
+	rv := reflect.ValueOf(v[0])
+	for rv.IsValid() && rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return nil		// reported while executing
+		}
+		rv = rv.Elem()
+	}
+	if !rv.IsValid() {
+		return checkParameterList(stmt, decl, v)
+	}
+
+	switch rv.Kind() {
+	case reflect.Struct :
+		if _, is := rv.Interface().(driver.Valuer); !is && rv.Type() != timeType {
+			return checkParameterStruct(stmt, decl, rv, -1)
+		}
+	case reflect.Map :
+		return checkParameterMap(stmt, decl, rv, -1)
+	case reflect.Slice, reflect.Array :
+		if rv.Type() != bytesType && !stmt.hasArrayBind() {
+			return checkParameterSlice(stmt, decl, rv)
+		}
+	}
+
+	return checkParameterList(stmt, decl, v)
+}
+
+func checkParameterSlice(stmt QueryStatement, decl *statementType, rv reflect.Value) error {
+	if rv.Len() == 0 {
+		return nil
+	}
+
+	elem := rv.Index(0)
+	for elem.Kind() == reflect.Interface || elem.Kind() == reflect.Ptr {
+		if elem.IsNil() {
+			break
+		}
+		elem = elem.Elem()
+	}
+
+	switch elem.Kind() {
+	case reflect.Struct, reflect.Map, reflect.Slice, reflect.Array :
+		if elem.Type() == timeType || elem.Type() == bytesType {
+			break
+		}
+		if _, is := elem.Interface().(driver.Valuer); is {
+			break
+		}
+		// batch parameters
+		for i := 0; i < rv.Len(); i++ {
+			item := rv.Index(i)
+			for item.Kind() == reflect.Interface || item.Kind() == reflect.Ptr {
+				if item.IsNil() {
+					return newStatementTypeError(stmt.Id, "", "nil parameter at index %d", i)
+				}
+				item = item.Elem()
+			}
+
+			var err error
+			switch item.Kind() {
+			case reflect.Struct :
+				err = checkParameterStruct(stmt, decl, item, i)
+			case reflect.Map :
+				err = checkParameterMap(stmt, decl, item, i)
+			default :
+				err = checkParameterList(stmt, decl, flattenToList(item.Interface()))
+			}
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	return checkParameterList(stmt, decl, flattenToList(rv.Interface()))
+}
+
+func checkParameterStruct(stmt QueryStatement, decl *statementType, rv reflect.Value, index int) error {
+	if t := decl.registeredType(); t != nil {
+		if rv.Type() != t {
+			return newStatementTypeError(stmt.Id, "", "paramType %s expected but %s passed%s", decl, rv.Type(), atIndex(index))
+		}
+		return nil
+	}
+
+	for _, c := range decl.columns {
+		f := rv.FieldByName(c.name)
+		if !f.IsValid() || !f.CanInterface() {
+			if !isRequiredColumn(stmt, c.name) {
+				continue
+			}
+			return newStatementTypeError(stmt.Id, c.name, "not found in %s%s", rv.Type(), atIndex(index))
+		}
+		if err := checkValueKind(stmt, c, f.Interface(), index); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func checkParameterMap(stmt QueryStatement, decl *statementType, rv reflect.Value, index int) error {
+	if rv.Type().Key().Kind() != reflect.String {
+		return nil		// reported while executing
+	}
+
+	lookup := func(name string) (interface{}, bool) {
+		found := rv.MapIndex(reflect.ValueOf(name).Convert(rv.Type().Key()))
+		if !found.IsValid() {
+			return nil, false
+		}
+		return found.Interface(), true
+	}
+
+	columns := decl.columns
+	if t := decl.registeredType(); t != nil && t.Kind() == reflect.Struct {
+		columns = make([]typedColumn, 0)
+		for i := 0; i < t.NumField(); i++ {
+			columns = append(columns, typedColumn{name: t.Field(i).Name, kind: kindOfType(t.Field(i).Type)})
+		}
+	}
+
+	// every required bind column should exist in map
+	for _, v := range stmt.columnMention {
+		if !v.required() {
+			continue
+		}
+		if _, ok := lookup(v.Name()); !ok {
+			return newStatementTypeError(stmt.Id, v.Name(), "not found from parameter values%s", atIndex(index))
+		}
+	}
+
+	for _, c := range columns {
+		value, ok := lookup(c.name)
+		if !ok {
+			if decl.isInline() && !stmt.HasCondition() && isRequiredColumn(stmt, c.name) {
+				return newStatementTypeError(stmt.Id, c.name, "not found from parameter values%s", atIndex(index))
+			}
+			continue
+		}
+		if err := checkValueKind(stmt, c, value, index); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func checkParameterList(stmt QueryStatement, decl *statementType, args []interface{}) error {
+	if t := decl.registeredType(); t != nil && len(args) == 1 && args[0] != nil {
+		return newStatementTypeError(stmt.Id, "", "paramType %s expected but %s passed", decl, reflect.TypeOf(args[0]))
+	}
+
+	if !decl.isInline() {
+		return nil
+	}
+
+	for i, v := range stmt.columnMention {
+		if i >= len(args) {
+			if !v.required() {
+				continue
+			}
+			return newStatementTypeError(stmt.Id, v.Name(), "no parameter at position %d", i)
+		}
+		c, ok := decl.findColumn(v.Name())
+		if !ok {
+			continue
+		}
+		if v.bindType == columnBindTypeArray {
+			values, _ := flattenArray(args[i])
+			for _, item := range values {
+				if err := checkValueKind(stmt, c, item, -1); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+		if err := checkValueKind(stmt, c, args[i], -1); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func checkValueKind(stmt QueryStatement, c typedColumn, value interface{}, index int) error {
+	kind, notNull := kindOfValue(value)
+	if !notNull {
+		return nil
+	}
+	if !c.kind.accept(kind) {
+		return newStatementTypeError(stmt.Id, c.name, "%s expected but %s(%T) passed%s", c.kind, kind, value, atIndex(index))
+	}
+	return nil
+}
+
+// isRequiredColumn reports whether column should be passed. columns which are declared
+// only in paramType are required
+func isRequiredColumn(stmt QueryStatement, name string) bool {
+	for _, v := range stmt.columnMention {
+		if strings.EqualFold(v.Name(), name) {
+			return v.required()
+		}
+	}
+	return true
+}
+
+// placeholderType builds inline declaration from typed placeholders. e.g) {Limit=100:int}
+func placeholderType(stmt QueryStatement) *statementType {
+	decl := &statementType{}
+	decl.columns = make([]typedColumn, 0)
+
+	declares := make([]string, 0)
+	for _, v := range stmt.columnMention {
+		if v.kind == columnKindAny {
+			continue
+		}
+		if _, exist := decl.findColumn(v.Name()); exist {
+			continue
+		}
+		decl.columns = append(decl.columns, typedColumn{name: v.Name(), kind: v.kind})
+		declares = append(declares, fmt.Sprintf("%s:%s", v.Name(), v.kind))
+	}
+
+	if len(decl.columns) == 0 {
+		return nil
+	}
+	decl.declare = delimStartString + strings.Join(declares, ",") + delimStopString
+	return decl
+}
+
+func atIndex(index int) string {
+	if index < 0 {
+		return ""
+	}
+	return fmt.Sprintf(" at index %d", index)
+}
+
+// checkResultColumns validates selected columns and scanning destination with resultType declaration
+func checkResultColumns(stmtId string, decl *statementType, converter FieldNameConvertStrategy, columns []string, dest []interface{}) error {
+	if decl == nil {
+		return nil
+	}
+
+	var structType reflect.Type
+	if len(dest) == 1 {
+		t := reflect.TypeOf(dest[0])
+		if t != nil && t.Kind() == reflect.Ptr && t.Elem().Kind() == reflect.Struct && t.Elem() != timeType {
+			if _, is := reflect.New(t.Elem()).Interface().(sql.Scanner); !is {
+				structType = t.Elem()
+			}
+		}
+	}
+
+	if t := decl.registeredType(); t != nil {
+		if structType == nil {
+			return nil
+		}
+		if structType != t {
+			return newStatementTypeError(stmtId, "", "resultType %s expected but scanning into %s", decl, structType)
+		}
+	}
+
+	for i, column := range columns {
+		fieldName := converter.convertFieldName(strings.ToLower(column))
+		var declared typedColumn
+		found := false
+		if decl.isInline() {
+			declared, found = decl.findColumn(fieldName)
+			if !found {
+				declared, found = decl.findColumn(column)
+			}
+			if !found {
+				return newStatementTypeError(stmtId, column, "result column is not declared in resultType %s", decl)
+			}
+		}
+
+		if structType != nil {
+			f, ok := structType.FieldByName(fieldName)
+			if !ok || len(f.PkgPath) > 0 {
+				return newStatementTypeError(stmtId, column, "result column has no exported field %s in %s", fieldName, structType)
+			}
+			if found && !declared.kind.accept(kindOfType(f.Type)) {
+				return newStatementTypeError(stmtId, column, "%s declared but field %s is %s", declared.kind, fieldName, f.Type)
+			}
+			continue
+		}
+
+		if !found {
+			continue
+		}
+		if i >= len(dest) {
+			return newStatementTypeError(stmtId, column, "no scanning destination at position %d", i)
+		}
+		t := reflect.TypeOf(dest[i])
+		if t != nil && t.Kind() == reflect.Ptr && !declared.kind.accept(kindOfType(t.Elem())) {
+			return newStatementTypeError(stmtId, column, "%s declared but scanning into %s", declared.kind, t.Elem())
+		}
+	}
+	return nil
+}
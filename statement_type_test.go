@@ -0,0 +1,169 @@
+//
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+//
+// @project queryman
+// @author agent
+// @date 2026. 10. 18. PM 11:12
+//
+
+package queryman
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+)
+
+func expectTypeError(t *testing.T, err error, field string) {
+	if err == nil {
+		t.Fatalf("expect type error for field [%s]", field)
+	}
+	typeErr, ok := err.(*StatementTypeError)
+	if !ok {
+		t.Fatalf("expect StatementTypeError but %T : %s", err, err.Error())
+	}
+	if typeErr.StmtId != "TestStmt" || typeErr.Field != field {
+		t.Fatalf("unexpected type error : %s", err.Error())
+	}
+}
+
+func TestParseStatementType(t *testing.T) {
+	decl, err := parseStatementType("{Name:string, Age:int, CreateTime:datetime}")
+	if err != nil {
+		t.Fatalf("fail to parse : %s", err.Error())
+	}
+	if !decl.isInline() || len(decl.columns) != 3 || decl.columns[2].kind != columnKindTime {
+		t.Fatalf("invalid inline declaration : %v", decl.columns)
+	}
+
+	decl, err = parseStatementType("*model.City")
+	if err != nil || decl.isInline() || decl.typeName != "model.City" {
+		t.Fatalf("invalid type name declaration")
+	}
+
+	invalid := []string{"{Name}", "{Name:strange}", "{Name:string", "{Age:int,Age:int}"}
+	for _, v := range invalid {
+		if _, err := parseStatementType(v); err == nil {
+			t.Errorf("expect error for %s", v)
+		}
+	}
+}
+
+func TestCheckInlineParameter(t *testing.T) {
+	stmt := buildTestStatement(t, "mysql", QueryStatement{Query: "UPDATE CITY SET AGE={Age} WHERE NAME={Name}", ParamType: "{Age:int,Name:string}"})
+
+	m := map[string]interface{}{"Age": 10, "Name": "seoul"}
+	if err := checkParameter(stmt, m); err != nil {
+		t.Fatalf("unexpected error : %s", err.Error())
+	}
+
+	delete(m, "Name")
+	expectTypeError(t, checkParameter(stmt, m), "Name")
+
+	m["Name"] = 42
+	expectTypeError(t, checkParameter(stmt, m), "Name")
+
+	m["Name"] = sql.NullString{String: "seoul", Valid: true}
+	if err := checkParameter(stmt, m); err != nil {
+		t.Fatalf("unexpected error : %s", err.Error())
+	}
+
+	m["Name"] = nil
+	if err := checkParameter(stmt, m); err != nil {
+		t.Fatalf("NULL should be accepted : %s", err.Error())
+	}
+
+	if err := checkParameter(stmt, 10, "seoul"); err != nil {
+		t.Fatalf("unexpected error : %s", err.Error())
+	}
+	expectTypeError(t, checkParameter(stmt, "ten", "seoul"), "Age")
+
+	type Town struct {
+		Age  int
+		Name string
+	}
+	if err := checkParameter(stmt, &Town{Age: 1, Name: "busan"}); err != nil {
+		t.Fatalf("unexpected error : %s", err.Error())
+	}
+
+	type Village struct {
+		Age int
+	}
+	expectTypeError(t, checkParameter(stmt, []Village{{Age: 1}}), "Name")
+}
+
+func TestCheckRegisteredParameter(t *testing.T) {
+	type TypedCity struct {
+		Name string
+		Age  int
+	}
+	type OtherCity struct {
+		Name string
+	}
+	RegisterType("TypedCity", TypedCity{})
+
+	stmt := buildTestStatement(t, "mysql", QueryStatement{Query: "INSERT INTO CITY(NAME,AGE) VALUES({Name},{Age})", ParamType: "*TypedCity"})
+	if err := checkParameter(stmt, &TypedCity{}); err != nil {
+		t.Fatalf("unexpected error : %s", err.Error())
+	}
+	if err := checkParameter(stmt, []TypedCity{{}, {}}); err != nil {
+		t.Fatalf("unexpected error : %s", err.Error())
+	}
+	expectTypeError(t, checkParameter(stmt, OtherCity{}), "")
+	expectTypeError(t, checkParameter(stmt, map[string]interface{}{"Name": "seoul", "Age": "old"}), "Age")
+	expectTypeError(t, checkParameter(stmt, map[string]interface{}{"Name": "seoul"}), "Age")
+	expectTypeError(t, checkParameter(stmt), "")
+
+	unregistered := buildTestStatement(t, "mysql", QueryStatement{Query: "INSERT INTO CITY(NAME) VALUES({Name})", ParamType: "NotRegistered"})
+	if err := checkParameter(unregistered, OtherCity{}); err != nil {
+		t.Fatalf("unregistered type should not be checked : %s", err.Error())
+	}
+}
+
+func TestCheckResultColumns(t *testing.T) {
+	type ResultCity struct {
+		Id         int
+		Name       string
+		CreateTime time.Time
+	}
+	converter := CamelConvertStrategy{}
+
+	decl, _ := parseStatementType("{Id:int,Name:string,CreateTime:time}")
+	columns := []string{"id", "name", "create_time"}
+	if err := checkResultColumns("TestStmt", decl, converter, columns, []interface{}{&ResultCity{}}); err != nil {
+		t.Fatalf("unexpected error : %s", err.Error())
+	}
+
+	var id int
+	var name string
+	var createTime time.Time
+	if err := checkResultColumns("TestStmt", decl, converter, columns, []interface{}{&id, &name, &createTime}); err != nil {
+		t.Fatalf("unexpected error : %s", err.Error())
+	}
+	expectTypeError(t, checkResultColumns("TestStmt", decl, converter, columns, []interface{}{&id, &id, &createTime}), "name")
+	expectTypeError(t, checkResultColumns("TestStmt", decl, converter, []string{"id", "age"}, []interface{}{&ResultCity{}}), "age")
+
+	RegisterType("ResultCity", ResultCity{})
+	decl, _ = parseStatementType("ResultCity")
+	expectTypeError(t, checkResultColumns("TestStmt", decl, converter, []string{"id", "unknown_column"}, []interface{}{&ResultCity{}}), "unknown_column")
+
+	type OtherResult struct {
+		Id int
+	}
+	expectTypeError(t, checkResultColumns("TestStmt", decl, converter, []string{"id"}, []interface{}{&OtherResult{}}), "")
+}
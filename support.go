@@ -83,78 +83,18 @@ func (u CamelConvertStrategy) convertFieldName(name string) string {
 }
 
 
-/*
-MySQL               PostgreSQL            Oracle
-=====               ==========            ======
-WHERE col = ?       WHERE col = $1        WHERE col = :col
-VALUES(?, ?, ?)     VALUES($1, $2, $3)    VALUES(:val1, :val2, :val3)
-*/
-
 func newNormalizer(driverName string) QueryNormalizer {
 	normalizer := &UserQueryNormalizer{}
-
-	switch(strings.ToLower(driverName)) {
-	case "postgresql" :
-		normalizer.strategy = &PostgreSQLPlaceholderStrategy{}
-	case "oci8" :
-		normalizer.strategy = &OraclePlaceholderStrategy{}
-	default :
-		normalizer.strategy = &MysqlPlaceholderStrategy{}
-	}
-
+	normalizer.dialect = findDialect(driverName)
 	return normalizer
 }
 
-type SqlVariablePlaceholderStrategy interface {
-	getNextMark() string
-	clone()	SqlVariablePlaceholderStrategy
-}
-
-type MysqlPlaceholderStrategy struct {
-}
-
-func (m *MysqlPlaceholderStrategy) getNextMark() string {
-	return "?"
-}
-
-func (m *MysqlPlaceholderStrategy) clone() SqlVariablePlaceholderStrategy {
-	return &MysqlPlaceholderStrategy{}
-}
-
-type PostgreSQLPlaceholderStrategy struct {
-	paramIndex		int
-}
-
-func (p *PostgreSQLPlaceholderStrategy) getNextMark() string {
-	val := fmt.Sprintf("$%d", p.paramIndex)
-	p.paramIndex++
-	return val
-}
-
-func (p *PostgreSQLPlaceholderStrategy) clone() SqlVariablePlaceholderStrategy {
-	n := &PostgreSQLPlaceholderStrategy{}
-	n.paramIndex = 0
-	return n
-}
-
-type OraclePlaceholderStrategy struct {
-	paramIndex		int
-}
-
-func (o *OraclePlaceholderStrategy) getNextMark() string {
-	val := fmt.Sprintf(":val%d", o.paramIndex)
-	o.paramIndex++
-	return val
-}
-
-func (p *OraclePlaceholderStrategy) clone() SqlVariablePlaceholderStrategy {
-	n := &OraclePlaceholderStrategy{}
-	n.paramIndex = 0
-	return n
+type UserQueryNormalizer struct {
+	dialect Dialect
 }
 
-type UserQueryNormalizer struct {
-	strategy SqlVariablePlaceholderStrategy
+func (n *UserQueryNormalizer) getDialect() Dialect {
+	return n.dialect
 }
 
 //var holdByte byte = '`'
@@ -167,48 +107,41 @@ func (n *UserQueryNormalizer) normalize(stmt *QueryStatement) error {
 		return fmt.Errorf("invalid query : %s", stmt.Query)
 	}
 
-	var hold	bytes.Buffer
+	tokens, err := newDialectLexer(n.dialect, stmt.Query).tokenize()
+	if err != nil {
+		return err
+	}
 
-	queryLen := len(stmt.Query)
-	for i:=0; i<queryLen; i++ {
-		ch := stmt.Query[i]
-		if ch != delimStartCharacter {
-			hold.WriteByte(ch)
+	var hold	bytes.Buffer
+	for _, token := range tokens {
+		if token.tokenType != sqlTokenPlaceholder {
+			hold.WriteString(token.text)
 			continue
 		}
 
-		if i >= queryLen - 2 {
-			return fmt.Errorf("incompleted variable closer : %s", stmt.Query)
+		bindType := columnBindType(columnBindTypeNormal)
+		if token.inClause {
+			bindType = columnBindTypeArray
 		}
-		stopIndex := strings.Index(stmt.Query[i+1:], delimStopString)
-		if stopIndex < 1 {
-			return fmt.Errorf("incompleted variable closer : %s", stmt.Query)
+		columnBind, err := parseColumnBind(token.text, hold.Len() + 1, bindType)
+		if err != nil {
+			return err
 		}
-
-		v := stmt.Query[i+1:i+1+stopIndex]
-		if strings.Index(v, delimStartString) >= 0 {
-			return fmt.Errorf("invalid variable declare format : %s", stmt.Query)
-		}
-
-		if isInClause(stmt.Query[:i])	{
-			stmt.columnMention = append(stmt.columnMention, NewColumnBindArray(v, hold.Len() + 1))
-		} else {
-			stmt.columnMention = append(stmt.columnMention, NewColumnBind(v, hold.Len() + 1))
-		}
-		i = i + stopIndex + 1
+		stmt.columnMention = append(stmt.columnMention, columnBind)
 		hold.WriteByte(holdByte)
 	}
 
 	stmt.HoldedQuery = hold.String()
-	stmt.Query = n.resolveHolding(stmt.HoldedQuery)
+	stmt.Query = n.resolveHolding(stmt.HoldedQuery, stmt.holdNames())
 	return nil
 }
 
 
-func (n *UserQueryNormalizer) resolveHolding(query string) string {
+// resolveHolding replaces holds with placeholder of dialect. names are bind names of holds in order
+func (n *UserQueryNormalizer) resolveHolding(query string, names []string) string {
 	var buffer bytes.Buffer
 
-	stgy := n.strategy.clone()
+	index := 0
 	queryLen := len(query)
 	for i:=0; i<queryLen; i++ {
 		ch := query[i]
@@ -217,25 +150,17 @@ func (n *UserQueryNormalizer) resolveHolding(query string) string {
 			continue
 		}
 
-		buffer.WriteString(stgy.getNextMark())
+		name := ""
+		if index < len(names) {
+			name = names[index]
+		}
+		index++
+		buffer.WriteString(n.dialect.Placeholder(index, name))
 	}
 
 	return buffer.String()
 }
 
-func isInClause(sqlPrefix string) bool 	{
-	s := strings.Replace(sqlPrefix, " ", "", -1)
-	s = strings.Replace(s, "\n", "", -1)
-	s = strings.Replace(s, "\r", "", -1)
-	if len(s) < 3 {
-		return false
-	}
-	if strings.ToUpper(s[len(s)-3:]) == "IN("	{
-		return true
-	}
-	return false
-}
-
 type StructureScanner struct {
 	scanIndex		int
 	fieldNameList	[]string
@@ -285,6 +210,27 @@ func (ss *StructureScanner) Scan(value interface{}) error {
 	return convertAssign(dest, value)
 }
 
+var timeLayouts = []string{
+	"2006-01-02 15:04:05.999999999Z07:00",
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02 15:04",
+	"2006-01-02T15:04",
+	"2006-01-02",
+}
+
+// parseTimeString parses time which is stored as text (e.g. sqlite)
+func parseTimeString(s string) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	for _, layout := range timeLayouts {
+		if t, err := time.ParseInLocation(layout, s, time.Local); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("unknown time format : %s", s)
+}
+
 func currentTimeMillis() int {
 	return int(time.Now().UnixNano() / 1000000)
 }
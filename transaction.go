@@ -24,6 +24,7 @@
 package queryman
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"runtime"
@@ -35,49 +36,274 @@ type DBTransaction struct {
 	queryFinder        QueryStatementFinder
 	fieldNameConverter FieldNameConvertStrategy
 	debugger           SqlDebugger
+	dialect            Dialect
+	savepoint          string
+	savepointSeq       *int
+	done               bool
+	readOnly           bool
+	cancel             context.CancelFunc
+	hooks              *txHooks
+	parentHooks        *txHooks
+	root               *DBTransaction	// keeps outermost transaction reachable from nested one
+	scopes             *[]*DBTransaction	// nested transactions begun in outermost transaction
+	source             *sql.DB
+	ctx                context.Context
+}
+
+// txHooks holds callbacks registered in transaction scope
+type txHooks struct {
+	commit   []func()
+	rollback []func(error)
 }
 
 func (t *DBTransaction) Rollback() error {
+	return t.rollbackWith(nil)
+}
+
+// rollbackWith rolls back and passes cause to rollback hooks
+func (t *DBTransaction) rollbackWith(cause error) (err error) {
 	defer func() {
 		if r := recover(); r != nil {
 			fmt.Println("Rollback panic", r)
 			return
 		}
 	}()
-	return t.tx.Rollback()
+	if t.isNested() {
+		return t.finishNested(SavepointRollback, cause)
+	}
+	defer t.release()
+	if t.tx != nil {
+		err = t.tx.Rollback()
+	}
+	t.finish(false, cause)
+	return err
 }
 
 func (t *DBTransaction) Commit() error {
-	return t.tx.Commit()
+	if t.isNested() {
+		return t.finishNested(SavepointRelease, nil)
+	}
+	defer t.release()
+	if t.tx == nil {
+		t.finish(true, nil)
+		return nil
+	}
+	err := t.tx.Commit()
+	if err != nil {
+		t.finish(false, err)
+	} else {
+		t.finish(true, nil)
+	}
+	return err
+}
+
+// OnCommit registers fn which runs after transaction is committed.
+// in nested transaction, fn runs when outermost transaction is committed
+func (t *DBTransaction) OnCommit(fn func()) {
+	t.hooks.commit = append(t.hooks.commit, fn)
+}
+
+// OnRollback registers fn which runs after transaction is rolled back.
+// fn receives cause of rollback. it is nil when Rollback is called.
+// hooks of leaked transaction run only if finalizer runs, which go runtime does not guarantee
+func (t *DBTransaction) OnRollback(fn func(error)) {
+	t.hooks.rollback = append(t.hooks.rollback, fn)
+}
+
+// finish runs hooks of outermost transaction once
+func (t *DBTransaction) finish(committed bool, cause error) {
+	if t.done {
+		return
+	}
+	t.done = true
+	runtime.SetFinalizer(t, nil)
+	t.closeScopes()
+
+	if committed {
+		t.runCommitHooks(t.hooks)
+	} else {
+		t.runRollbackHooks(t.hooks, cause)
+	}
+}
+
+// closeScopes finishes nested transactions which are still open when outermost transaction finishes.
+// their hooks are handed over to parent from innermost one so that they run with hooks of outermost transaction
+func (t *DBTransaction) closeScopes() {
+	scopes := *t.scopes
+	for i := len(scopes) - 1; i >= 0; i-- {
+		scope := scopes[i]
+		if scope.done {
+			continue
+		}
+		scope.done = true
+		scope.parentHooks.commit = append(scope.parentHooks.commit, scope.hooks.commit...)
+		scope.parentHooks.rollback = append(scope.parentHooks.rollback, scope.hooks.rollback...)
+	}
+	*t.scopes = nil
+}
+
+func (t *DBTransaction) runCommitHooks(hooks *txHooks) {
+	for _, fn := range hooks.commit {
+		func() {
+			defer t.recoverHook()
+			fn()
+		}()
+	}
+}
+
+func (t *DBTransaction) runRollbackHooks(hooks *txHooks, cause error) {
+	for _, fn := range hooks.rollback {
+		func() {
+			defer t.recoverHook()
+			fn(cause)
+		}()
+	}
+}
+
+func (t *DBTransaction) recoverHook() {
+	if r := recover(); r != nil {
+		t.debugger.errorPrint("transaction hook panic : %v", r)
+	}
 }
 
-func newTransaction(debugger SqlDebugger, tx *sql.Tx, queryFinder QueryStatementFinder, fieldNameConverter FieldNameConvertStrategy) *DBTransaction {
+// release stops timeout of transaction
+func (t *DBTransaction) release() {
+	if t.cancel != nil {
+		t.cancel()
+	}
+}
+
+func (t *DBTransaction) IsReadOnly() bool {
+	return t.readOnly
+}
+
+func newTransaction(debugger SqlDebugger, tx *sql.Tx, queryFinder QueryStatementFinder, fieldNameConverter FieldNameConvertStrategy, dialect Dialect) *DBTransaction {
 	dbTransaction := DBTransaction{}
 	dbTransaction.debugger = debugger
 	dbTransaction.tx = tx
 	dbTransaction.queryFinder = queryFinder
 	dbTransaction.fieldNameConverter = fieldNameConverter
+	dbTransaction.dialect = dialect
+	dbTransaction.savepointSeq = new(int)
+	dbTransaction.hooks = &txHooks{}
+	dbTransaction.scopes = new([]*DBTransaction)
 	return &dbTransaction
 }
 
+// Begin starts nested transaction on a savepoint.
+// Commit of nested transaction releases the savepoint and Rollback rolls back to it
+func (t *DBTransaction) Begin() (*DBTransaction, error) {
+	*t.savepointSeq++
+	name := fmt.Sprintf("queryman_sp_%d", *t.savepointSeq)
+	if err := t.Savepoint(name); err != nil {
+		return nil, err
+	}
+
+	nested := *t
+	nested.savepoint = name
+	nested.done = false
+	nested.hooks = &txHooks{}
+	nested.parentHooks = t.hooks
+	nested.root = t.root
+	if nested.root == nil {
+		nested.root = t
+	}
+	*t.scopes = append(*t.scopes, &nested)
+	return &nested, nil
+}
+
+func (t *DBTransaction) isNested() bool {
+	return len(t.savepoint) > 0
+}
+
+// finishNested releases or rolls back to savepoint. hooks of released scope are handed over to parent,
+// rollback hooks of rolled back scope run at once
+func (t *DBTransaction) finishNested(action SavepointAction, cause error) error {
+	if t.done {
+		return sql.ErrTxDone
+	}
+	t.done = true
+
+	err := t.execSavepoint(action, t.savepoint)
+	if action == SavepointRelease && err == nil {
+		t.parentHooks.commit = append(t.parentHooks.commit, t.hooks.commit...)
+		t.parentHooks.rollback = append(t.parentHooks.rollback, t.hooks.rollback...)
+	} else if action == SavepointRollback {
+		t.runRollbackHooks(t.hooks, cause)
+	}
+	return err
+}
+
+func (t *DBTransaction) Savepoint(name string) error {
+	return t.execSavepoint(SavepointCreate, name)
+}
+
+func (t *DBTransaction) RollbackTo(name string) error {
+	return t.execSavepoint(SavepointRollback, name)
+}
+
+// Release releases savepoint. it does nothing on dialect which has no release statement
+func (t *DBTransaction) Release(name string) error {
+	return t.execSavepoint(SavepointRelease, name)
+}
+
+func (t *DBTransaction) execSavepoint(action SavepointAction, name string) error {
+	if t.tx == nil {
+		return ErrNotInTransaction
+	}
+	if !isSavepointName(name) {
+		return fmt.Errorf("invalid savepoint name : %s", name)
+	}
+
+	query := t.dialect.Savepoint(action, name)
+	if len(query) == 0 {
+		return nil
+	}
+	if t.debugEnabled() {
+		t.debugPrint("%s", query)
+	}
+	_, err := t.tx.Exec(query)
+	return err
+}
+
+func isSavepointName(name string) bool {
+	if len(name) == 0 {
+		return false
+	}
+	for i := 0; i < len(name); i++ {
+		if name[i] == '$' || !isIdentifierByte(name[i]) {
+			return false
+		}
+	}
+	return true
+}
+
 func (t *DBTransaction) exec(query string, args ...interface{}) (sql.Result, error) {
-	return t.tx.Exec(query, args...)
+	return t.conn().ExecContext(context.Background(), query, args...)
 }
 
 func (t *DBTransaction) query(query string, args ...interface{}) (*sql.Rows, error) {
-	return t.tx.Query(query, args...)
+	return t.conn().QueryContext(context.Background(), query, args...)
 }
 
 func (t *DBTransaction) queryRow(query string, args ...interface{}) *sql.Row {
-	return t.tx.QueryRow(query, args...)
+	return t.conn().QueryRowContext(context.Background(), query, args...)
 }
 
 func (t *DBTransaction) prepare(query string) (*sql.Stmt, error) {
-	return t.tx.Prepare(query)
+	return t.conn().PrepareContext(context.Background(), query)
+}
+
+// conn returns connection of transaction. non transactional executor runs on datasource
+func (t *DBTransaction) conn() sqlConn {
+	if t.tx == nil {
+		return t.source
+	}
+	return t.tx
 }
 
 func (t *DBTransaction) isTransaction() bool {
-	return true
+	return t.tx != nil
 }
 
 func (t *DBTransaction) debugEnabled() bool {
@@ -88,6 +314,10 @@ func (t *DBTransaction) debugPrint(format string, params ...interface{}) {
 	t.debugger.debugPrint(format, params...)
 }
 
+func (t *DBTransaction) errorPrint(format string, params ...interface{}) {
+	t.debugger.errorPrint(format, params...)
+}
+
 func (t *DBTransaction) recordExcution(stmtId string, start time.Time) {
 	t.debugger.recordExcution(stmtId, start)
 }
@@ -99,6 +329,15 @@ func (t *DBTransaction) CreateBulk() (Bulk, error) {
 }
 
 func (t *DBTransaction) CreateBulkWithStmt(stmtIdOrUserQuery string) (Bulk, error) {
+	return t.CreateBulkWithStmtContext(context.Background(), stmtIdOrUserQuery)
+}
+
+func (t *DBTransaction) CreateBulkWithStmtContext(ctx context.Context, stmtIdOrUserQuery string) (Bulk, error) {
+	return t.CreateBulkWithOptions(ctx, stmtIdOrUserQuery, NewBulkOptions())
+}
+
+// CreateBulkWithOptions creates bulk which splits insert into chunks by opts. InTransaction is ignored
+func (t *DBTransaction) CreateBulkWithOptions(ctx context.Context, stmtIdOrUserQuery string, opts BulkOptions) (Bulk, error) {
 	stmt, err := t.queryFinder.find(stmtIdOrUserQuery)
 	if err != nil {
 		return nil, err
@@ -107,8 +346,14 @@ func (t *DBTransaction) CreateBulkWithStmt(stmtIdOrUserQuery string) (Bulk, erro
 	if stmt.eleType != eleTypeInsert && stmt.eleType != eleTypeUpdate {
 		return nil, ErrExecutionInvalidSqlType
 	}
+	if t.readOnly {
+		return nil, ErrReadOnlyTransaction
+	}
+	if err = checkBulkStatement(stmt); err != nil {
+		return nil, err
+	}
 
-	bulk := newQuerymanBulk(t, stmt)
+	bulk := newQuerymanBulk(t.proxy(ctx), stmt, opts)
 	return bulk, nil
 }
 
@@ -119,6 +364,10 @@ func (t *DBTransaction) Execute(v ...interface{}) (sql.Result, error) {
 }
 
 func (t *DBTransaction) ExecuteWithStmt(id string, v ...interface{}) (sql.Result, error) {
+	return t.ExecuteWithStmtContext(context.Background(), id, v...)
+}
+
+func (t *DBTransaction) ExecuteWithStmtContext(ctx context.Context, id string, v ...interface{}) (sql.Result, error) {
 	stmt, err := t.queryFinder.find(id)
 	if err != nil {
 		return nil, err
@@ -127,8 +376,11 @@ func (t *DBTransaction) ExecuteWithStmt(id string, v ...interface{}) (sql.Result
 	if stmt.eleType != eleTypeInsert && stmt.eleType != eleTypeUpdate {
 		return nil, ErrExecutionInvalidSqlType
 	}
+	if t.readOnly {
+		return nil, ErrReadOnlyTransaction
+	}
 
-	return execute(t, stmt, v...)
+	return execute(t.proxy(ctx), stmt, v...)
 }
 
 func (t *DBTransaction) Query(v ...interface{}) *QueryResult {
@@ -138,6 +390,10 @@ func (t *DBTransaction) Query(v ...interface{}) *QueryResult {
 }
 
 func (t *DBTransaction) QueryWithStmt(id string, v ...interface{}) *QueryResult {
+	return t.QueryWithStmtContext(context.Background(), id, v...)
+}
+
+func (t *DBTransaction) QueryWithStmtContext(ctx context.Context, id string, v ...interface{}) *QueryResult {
 	stmt, err := t.queryFinder.find(id)
 	if err != nil {
 		return newQueryResultError(err)
@@ -147,8 +403,10 @@ func (t *DBTransaction) QueryWithStmt(id string, v ...interface{}) *QueryResult
 		return newQueryResultError(ErrQueryInvalidSqlType)
 	}
 
-	queryedRow := queryMultiRow(t, stmt, v...)
+	queryedRow := queryMultiRow(t.proxy(ctx), stmt, v...)
+	queryedRow.tx = t
 	queryedRow.fieldNameConverter = t.fieldNameConverter
+	queryedRow.resultCheck.bind(stmt)
 	return queryedRow
 }
 
@@ -159,6 +417,10 @@ func (t *DBTransaction) QueryRow(v ...interface{}) *QueryRowResult {
 }
 
 func (t *DBTransaction) QueryRowWithStmt(id string, v ...interface{}) *QueryRowResult {
+	return t.QueryRowWithStmtContext(context.Background(), id, v...)
+}
+
+func (t *DBTransaction) QueryRowWithStmtContext(ctx context.Context, id string, v ...interface{}) *QueryRowResult {
 	stmt, err := t.queryFinder.find(id)
 	if err != nil {
 		return newQueryRowResultError(err)
@@ -169,7 +431,7 @@ func (t *DBTransaction) QueryRowWithStmt(id string, v ...interface{}) *QueryRowR
 	}
 
 	var queryRowResult *QueryRowResult
-	queryResult := queryMultiRow(t, stmt, v...)
+	queryResult := queryMultiRow(t.proxy(ctx), stmt, v...)
 	if queryResult.err != nil {
 		queryRowResult = newQueryRowResultError(queryResult.err)
 	} else {
@@ -178,7 +440,11 @@ func (t *DBTransaction) QueryRowWithStmt(id string, v ...interface{}) *QueryRowR
 
 	queryResult.pstmt = nil
 	queryResult.rows = nil
+	queryRowResult.tx = t
 	queryRowResult.fieldNameConverter = t.fieldNameConverter
-	queryRowResult.SetTransaction()
+	queryRowResult.resultCheck.bind(stmt)
+	if t.tx != nil {
+		queryRowResult.SetTransaction()
+	}
 	return queryRowResult
 }
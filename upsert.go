@@ -0,0 +1,166 @@
+//
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+//
+// @project queryman
+// @author agent
+// @date 2026. 10. 19. AM 12:12
+//
+
+package queryman
+
+import (
+	"fmt"
+	"strings"
+)
+
+// renderUpsert rewrites insert of <upsert> element into upsert sql of dialect
+func renderUpsert(stmt *QueryStatement, dialect Dialect) error {
+	if stmt.HasCondition() {
+		return fmt.Errorf("upsert [%s] does not support dynamic clause", stmt.Id)
+	}
+	if len(strings.TrimSpace(stmt.ConflictKey)) == 0 {
+		return fmt.Errorf("conflictKey is required for upsert [%s]", stmt.Id)
+	}
+
+	insert, err := parseInsert(dialect, stmt.Query)
+	if err != nil {
+		return fmt.Errorf("invalid upsert [%s] : %s", stmt.Id, err.Error())
+	}
+	if len(insert.columns) == 0 {
+		return fmt.Errorf("upsert [%s] should declare column list", stmt.Id)
+	}
+	if len(strings.Trim(insert.suffix, "; \r\t\n")) > 0 {
+		return fmt.Errorf("upsert [%s] should end with VALUES clause", stmt.Id)
+	}
+
+	conflictKey, err := findUpsertColumns(insert.columns, stmt.ConflictKey)
+	if err != nil {
+		return fmt.Errorf("invalid conflictKey of upsert [%s] : %s", stmt.Id, err.Error())
+	}
+
+	var updateColumns []string
+	if len(strings.TrimSpace(stmt.UpdateColumns)) == 0 {
+		updateColumns = excludeColumns(insert.columns, conflictKey)
+	} else {
+		updateColumns, err = findUpsertColumns(insert.columns, stmt.UpdateColumns)
+		if err != nil {
+			return fmt.Errorf("invalid updateColumns of upsert [%s] : %s", stmt.Id, err.Error())
+		}
+	}
+
+	stmt.Query = dialect.Upsert(insert.table, insert.columns, insert.values, conflictKey, updateColumns)
+	return nil
+}
+
+// findUpsertColumns finds comma separated names in insert columns. name is compared without quote
+func findUpsertColumns(columns []string, names string) ([]string, error) {
+	found := make([]string, 0)
+	for _, name := range strings.Split(names, ",") {
+		name = unquoteColumn(name)
+		if len(name) == 0 {
+			continue
+		}
+		matched := false
+		for _, column := range columns {
+			if strings.EqualFold(unquoteColumn(column), name) {
+				found = append(found, column)
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return nil, fmt.Errorf("column %s is not in insert column list", name)
+		}
+	}
+	return found, nil
+}
+
+func excludeColumns(columns []string, excludes []string) []string {
+	list := make([]string, 0)
+	for _, column := range columns {
+		excluded := false
+		for _, v := range excludes {
+			if column == v {
+				excluded = true
+				break
+			}
+		}
+		if !excluded {
+			list = append(list, column)
+		}
+	}
+	return list
+}
+
+func unquoteColumn(name string) string {
+	return strings.Trim(strings.TrimSpace(name), "`\"[]")
+}
+
+func insertSql(table string, columns []string, values []string) string {
+	return fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s)", table, strings.Join(columns, ", "), strings.Join(values, ", "))
+}
+
+// upsertOnDuplicateKey uses ON DUPLICATE KEY UPDATE of MySQL. conflict key is decided by unique index
+func upsertOnDuplicateKey(table string, columns []string, values []string, conflictKey []string, updateColumns []string) string {
+	assign := make([]string, 0)
+	for _, v := range updateColumns {
+		assign = append(assign, fmt.Sprintf("%s = VALUES(%s)", v, v))
+	}
+	if len(assign) == 0 {
+		assign = append(assign, fmt.Sprintf("%s = %s", conflictKey[0], conflictKey[0]))
+	}
+	return fmt.Sprintf("%s ON DUPLICATE KEY UPDATE %s", insertSql(table, columns, values), strings.Join(assign, ", "))
+}
+
+// upsertOnConflict uses ON CONFLICT clause of PostgreSQL and SQLite
+func upsertOnConflict(table string, columns []string, values []string, conflictKey []string, updateColumns []string) string {
+	if len(updateColumns) == 0 {
+		return fmt.Sprintf("%s ON CONFLICT (%s) DO NOTHING", insertSql(table, columns, values), strings.Join(conflictKey, ", "))
+	}
+
+	assign := make([]string, 0)
+	for _, v := range updateColumns {
+		assign = append(assign, fmt.Sprintf("%s = EXCLUDED.%s", v, v))
+	}
+	return fmt.Sprintf("%s ON CONFLICT (%s) DO UPDATE SET %s", insertSql(table, columns, values),
+		strings.Join(conflictKey, ", "), strings.Join(assign, ", "))
+}
+
+// mergeSql builds MERGE statement with source s. source is given by dialect
+func mergeSql(table string, source string, columns []string, conflictKey []string, updateColumns []string) string {
+	on := make([]string, 0)
+	for _, v := range conflictKey {
+		on = append(on, fmt.Sprintf("t.%s = s.%s", v, v))
+	}
+	inserts := make([]string, 0)
+	for _, v := range columns {
+		inserts = append(inserts, "s."+v)
+	}
+
+	var sql strings.Builder
+	sql.WriteString(fmt.Sprintf("MERGE INTO %s t USING %s ON (%s)", table, source, strings.Join(on, " AND ")))
+	if len(updateColumns) > 0 {
+		assign := make([]string, 0)
+		for _, v := range updateColumns {
+			assign = append(assign, fmt.Sprintf("t.%s = s.%s", v, v))
+		}
+		sql.WriteString(" WHEN MATCHED THEN UPDATE SET " + strings.Join(assign, ", "))
+	}
+	sql.WriteString(fmt.Sprintf(" WHEN NOT MATCHED THEN INSERT (%s) VALUES (%s)", strings.Join(columns, ", "), strings.Join(inserts, ", ")))
+	return sql.String()
+}
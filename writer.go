@@ -0,0 +1,290 @@
+//
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+//
+// @project queryman
+// @author agent
+// @date 2026. 10. 19. AM 12:07
+//
+
+package queryman
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// BulkWriterOptions configures BulkWriter. rows are flushed when FlushRows rows are buffered
+// or every FlushInterval. with Async, flushes run in a background goroutine and Write blocks
+// while QueueSize flushes are pending. Progress is called after each flush and must not call the writer
+type BulkWriterOptions struct {
+	FlushRows     int
+	FlushInterval time.Duration
+	Async         bool
+	QueueSize     int
+	Bulk          BulkOptions
+	Progress      func(progress BulkProgress)
+}
+
+func NewBulkWriterOptions() BulkWriterOptions {
+	opts := BulkWriterOptions{}
+	opts.FlushRows = 1000
+	opts.FlushInterval = 0
+	opts.Async = false
+	opts.QueueSize = 4
+	opts.Bulk = NewBulkOptions()
+	return opts
+}
+
+// BulkProgress reports a flush of BulkWriter
+type BulkProgress struct {
+	Flush     int
+	Rows      int
+	TotalRows int64
+	Err       error
+}
+
+// BulkFlushError is error of a flush
+type BulkFlushError struct {
+	Flush int
+	Rows  int
+	Err   error
+}
+
+func (e BulkFlushError) Error() string {
+	return fmt.Sprintf("fail to flush %d (%d rows) : %s", e.Flush, e.Rows, e.Err.Error())
+}
+
+// BulkWriter streams rows into bulk statements which are flushed automatically
+type BulkWriter struct {
+	mutex      sync.Mutex	// guards buffer and flush sequence
+	stateMutex sync.Mutex	// guards results of flushes
+	opts       BulkWriterOptions
+	newBulk    func() (Bulk, error)
+	current    *querymanBulk
+	flushSeq   int
+	totalRows  int64
+	errors     []BulkFlushError
+	reported   int
+	closed     bool
+	queue      chan bulkFlush
+	pending    int
+	drained    *sync.Cond
+	workerDone chan struct{}
+	stopTicker chan struct{}
+}
+
+type bulkFlush struct {
+	seq  int
+	rows int	// rows of bulk. bulk is cleared by successful Execute
+	bulk *querymanBulk
+}
+
+// CreateBulkWriter creates BulkWriter of insert or update statement
+func (man *QueryMan) CreateBulkWriter(ctx context.Context, stmtIdOrUserQuery string, opts BulkWriterOptions) (*BulkWriter, error) {
+	newBulk := func() (Bulk, error) {
+		return man.CreateBulkWithOptions(ctx, stmtIdOrUserQuery, opts.Bulk)
+	}
+	if _, err := newBulk(); err != nil {
+		return nil, err
+	}
+
+	w := &BulkWriter{}
+	w.opts = opts
+	w.newBulk = newBulk
+	w.errors = make([]BulkFlushError, 0)
+	w.drained = sync.NewCond(&w.stateMutex)
+	if opts.Async {
+		queueSize := opts.QueueSize
+		if queueSize < 1 {
+			queueSize = 1
+		}
+		w.queue = make(chan bulkFlush, queueSize)
+		w.workerDone = make(chan struct{})
+		go w.work()
+	}
+	if opts.FlushInterval > 0 {
+		w.stopTicker = make(chan struct{})
+		go w.tick(opts.FlushInterval)
+	}
+	return w, nil
+}
+
+// Write adds rows. same parameters as Bulk.AddBatch are accepted
+func (w *BulkWriter) Write(params ...interface{}) error {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	if w.closed {
+		return ErrBulkWriterClosed
+	}
+	if w.current == nil {
+		bulk, err := w.newBulk()
+		if err != nil {
+			return err
+		}
+		w.current = bulk.(*querymanBulk)
+	}
+
+	err := w.current.AddBatch(params...)
+	if err != nil {
+		return err
+	}
+	if w.opts.FlushRows > 0 && w.current.execCount >= w.opts.FlushRows {
+		w.flushLocked()
+	}
+	return nil
+}
+
+// Flush executes buffered rows and waits pending flushes.
+// it returns the first error of flushes failed since last Flush
+func (w *BulkWriter) Flush() error {
+	w.mutex.Lock()
+	w.flushLocked()
+	w.mutex.Unlock()
+
+	w.stateMutex.Lock()
+	for w.pending > 0 {
+		w.drained.Wait()
+	}
+	w.stateMutex.Unlock()
+	return w.takeError()
+}
+
+// Close flushes buffered rows and stops background goroutines. BulkWriter can not be used after Close
+func (w *BulkWriter) Close() error {
+	w.mutex.Lock()
+	if w.closed {
+		w.mutex.Unlock()
+		return nil
+	}
+	w.flushLocked()
+	w.closed = true
+	w.mutex.Unlock()
+
+	if w.stopTicker != nil {
+		close(w.stopTicker)
+	}
+	if w.queue != nil {
+		close(w.queue)
+		<-w.workerDone
+	}
+	return w.takeError()
+}
+
+// Errors returns errors of every failed flush
+func (w *BulkWriter) Errors() []BulkFlushError {
+	w.stateMutex.Lock()
+	defer w.stateMutex.Unlock()
+	return append([]BulkFlushError(nil), w.errors...)
+}
+
+// TotalRows returns count of rows flushed successfully
+func (w *BulkWriter) TotalRows() int64 {
+	w.stateMutex.Lock()
+	defer w.stateMutex.Unlock()
+	return w.totalRows
+}
+
+func (w *BulkWriter) takeError() error {
+	w.stateMutex.Lock()
+	defer w.stateMutex.Unlock()
+
+	if w.reported >= len(w.errors) {
+		return nil
+	}
+	err := w.errors[w.reported]
+	w.reported = len(w.errors)
+	return err
+}
+
+// flushLocked hands buffered rows over to worker or executes them. Write blocks here when queue is full
+func (w *BulkWriter) flushLocked() {
+	if w.current == nil || w.current.execCount == 0 {
+		return
+	}
+
+	w.flushSeq++
+	flush := bulkFlush{seq: w.flushSeq, rows: w.current.execCount, bulk: w.current}
+	w.current = nil
+
+	if w.queue != nil {
+		w.stateMutex.Lock()
+		w.pending++
+		w.stateMutex.Unlock()
+		w.queue <- flush
+		return
+	}
+
+	progress := w.record(flush, w.execute(flush))
+	if w.opts.Progress != nil {
+		w.opts.Progress(progress)
+	}
+}
+
+func (w *BulkWriter) execute(flush bulkFlush) error {
+	_, err := flush.bulk.Execute()
+	return err
+}
+
+func (w *BulkWriter) record(flush bulkFlush, err error) BulkProgress {
+	w.stateMutex.Lock()
+	defer w.stateMutex.Unlock()
+
+	rows := flush.rows
+	if err != nil {
+		w.errors = append(w.errors, BulkFlushError{Flush: flush.seq, Rows: rows, Err: err})
+	} else {
+		w.totalRows += int64(rows)
+	}
+	return BulkProgress{Flush: flush.seq, Rows: rows, TotalRows: w.totalRows, Err: err}
+}
+
+func (w *BulkWriter) work() {
+	defer close(w.workerDone)
+	for flush := range w.queue {
+		progress := w.record(flush, w.execute(flush))
+		if w.opts.Progress != nil {
+			w.opts.Progress(progress)
+		}
+
+		w.stateMutex.Lock()
+		w.pending--
+		w.drained.Broadcast()
+		w.stateMutex.Unlock()
+	}
+}
+
+func (w *BulkWriter) tick(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-w.stopTicker :
+			return
+		case <-ticker.C :
+			w.mutex.Lock()
+			if !w.closed {
+				w.flushLocked()
+			}
+			w.mutex.Unlock()
+		}
+	}
+}